@@ -0,0 +1,121 @@
+package campaign
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/hculpan/kabtrek/galaxy"
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/quadrant"
+	"github.com/hculpan/kabtrek/scenario"
+)
+
+// Campaign is an ordered list of missions flown by the same ship
+type Campaign struct {
+	Name     string    `json:"name"`
+	Missions []Mission `json:"missions"`
+}
+
+// Mission is one scenario in a campaign, with the text
+// shown before and after it
+type Mission struct {
+	Name       string   `json:"name"`
+	Briefing   []string `json:"briefing"`
+	Objectives []string `json:"objectives"`
+	Debrief    Debrief  `json:"debrief"`
+
+	// Scenario is a scenario file, relative to the campaign
+	// file.  Setup may be used instead to give it inline.
+	Scenario string             `json:"scenario"`
+	Setup    *scenario.Scenario `json:"setup"`
+}
+
+// Debrief is the text shown once a mission is over
+type Debrief struct {
+	Success []string `json:"success"`
+	Failure []string `json:"failure"`
+}
+
+// Load reads a campaign file and every mission's scenario
+func Load(path string) (*Campaign, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Campaign{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if len(result.Missions) == 0 {
+		return nil, fmt.Errorf("%s: campaign has no missions", path)
+	}
+
+	for i := range result.Missions {
+		m := &result.Missions[i]
+		switch {
+		case m.Setup != nil && m.Scenario != "":
+			return nil, fmt.Errorf("%s: mission %d (%s) has both a scenario file and an inline setup", path, i+1, m.Name)
+		case m.Setup != nil:
+			if err := m.Setup.Validate(); err != nil {
+				return nil, fmt.Errorf("%s: mission %d (%s): %v", path, i+1, m.Name, err)
+			}
+		case m.Scenario != "":
+			s, err := scenario.Load(filepath.Join(filepath.Dir(path), m.Scenario))
+			if err != nil {
+				return nil, fmt.Errorf("%s: mission %d (%s): %v", path, i+1, m.Name, err)
+			}
+			m.Setup = s
+		default:
+			return nil, fmt.Errorf("%s: mission %d (%s) has no scenario", path, i+1, m.Name)
+		}
+	}
+
+	return result, nil
+}
+
+// Current returns the mission the save is up to, or nil
+// once the campaign is complete
+func (c *Campaign) Current(s *Save) *Mission {
+	if s.Mission < 0 || s.Mission >= len(c.Missions) {
+		return nil
+	}
+	return &c.Missions[s.Mission]
+}
+
+// Start builds the galaxy for the save's current mission,
+// bringing the ship over from the last mission
+func (c *Campaign) Start(s *Save, rules *game.Rules, rnd *game.Random) *galaxy.Galaxy {
+	m := c.Current(s)
+	g := m.Setup.Build(rules, rnd)
+
+	if m.Setup.Stardate == 0 && s.Stardate > 0 {
+		g.Stardate = s.Stardate
+		g.StartingStardate = s.Stardate
+	}
+
+	if s.Ship != nil {
+		// Keep the scenario's position, but everything else
+		// about the ship carries over
+		ship := *s.Ship
+		ship.X, ship.Y = g.Player.X, g.Player.Y
+		ship.QuadrantX, ship.QuadrantY = g.Player.QuadrantX, g.Player.QuadrantY
+		ship.Orbiting = false
+		ship.Casualties = 0
+		if ship.Crew == 0 && ship.Morale == 0 {
+			// Saved before the crew was tracked
+			ship.Crew, ship.Morale = game.EnterpriseMaxCrew, quadrant.StartingMorale
+		}
+		if ship.EnginePower+ship.ShieldPower+ship.WeaponPower == 0 {
+			// Saved before power could be allocated
+			share := quadrant.PowerUnits / 3
+			ship.EnginePower, ship.ShieldPower, ship.WeaponPower = share, share, share
+		}
+		ship.LifeSupport = quadrant.LifeSupportReserve
+		*g.Player = ship
+	}
+
+	return g
+}
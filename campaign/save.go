@@ -0,0 +1,97 @@
+package campaign
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hculpan/kabtrek/galaxy"
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/quadrant"
+)
+
+// Save is the persistent state of a campaign in progress
+type Save struct {
+	Campaign string               `json:"campaign"`
+	Mission  int                  `json:"mission"`
+	Stardate float64              `json:"stardate"`
+	Ship     *quadrant.Enterprise `json:"ship"`
+	Log      []Result             `json:"log"`
+}
+
+// Result records how a single attempt at a mission went
+type Result struct {
+	Mission             string  `json:"mission"`
+	Won                 bool    `json:"won"`
+	Outcome             int     `json:"outcome"`
+	Stardates           float64 `json:"stardates"`
+	KlingonsDestroyed   int     `json:"klingons_destroyed"`
+	CommandersDestroyed int     `json:"commanders_destroyed"`
+	StarbasesLost       int     `json:"starbases_lost"`
+	Casualties          int     `json:"casualties"`
+}
+
+// LoadSave reads a campaign save, returning a fresh save
+// for the campaign if there is none yet.  A save from some
+// other campaign is an error.
+func LoadSave(path string, c *Campaign) (*Save, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Save{Campaign: c.Name}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	result := &Save{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+	if result.Campaign != c.Name {
+		return nil, fmt.Errorf("save is for the campaign %q, not %q", result.Campaign, c.Name)
+	}
+	return result, nil
+}
+
+// Write stores the save on disk
+func (s *Save) Write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Finish records the outcome of the current mission.  A
+// won mission moves the campaign on and keeps the ship as
+// it is now; a lost one is flown again with the ship as it
+// was before.
+func (s *Save) Finish(m *Mission, g *galaxy.Galaxy, outcome int) Result {
+	result := Result{
+		Mission:           m.Name,
+		Won:               outcome == game.Won,
+		Outcome:           outcome,
+		Stardates:         g.Stardate - g.StartingStardate,
+		KlingonsDestroyed: g.StartingNumberOfKlingons - g.NumberOfKlingons,
+		StarbasesLost:     g.StartingNumberOfStarbases - g.NumberOfStarbases,
+		Casualties:        g.Player.Casualties,
+		CommandersDestroyed: g.StartingNumberOfCommanders - g.NumberOfCommanders +
+			g.StartingNumberOfSuperCommanders - g.NumberOfSuperCommanders,
+	}
+	s.Log = append(s.Log, result)
+
+	if result.Won {
+		// A landing party still away is picked up on the way out
+		if g.Player.Party != quadrant.PartyAboard {
+			g.Player.Crystals += g.Player.PartyCrystals
+			g.Player.PartyCrystals = 0
+			g.Player.Party = quadrant.PartyAboard
+		}
+		ship := *g.Player
+		s.Ship = &ship
+		s.Stardate = g.Stardate
+		s.Mission++
+	}
+
+	return result
+}
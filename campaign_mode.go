@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/hculpan/kabtrek/campaign"
+	"github.com/hculpan/kabtrek/game"
+)
+
+// runCampaign plays a campaign from its save, returning
+// the exit code
+func runCampaign(campaignFile, saveFile string, turnBased bool) int {
+	c, err := campaign.Load(campaignFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if saveFile == "" {
+		saveFile = strings.TrimSuffix(campaignFile, ".json") + ".save.json"
+	}
+	save, err := campaign.LoadSave(saveFile, c)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", saveFile, err)
+		return 1
+	}
+
+	if err := game.InitScreen(); err != nil {
+		fmt.Fprintf(os.Stderr, err.Error())
+		return 1
+	}
+	defer game.CloseScreen()
+
+	ch := game.PollForEvents()
+	for {
+		m := c.Current(save)
+		if m == nil {
+			// The save is kept, with its record of the
+			// campaign, and shows it again next time
+			campaignCompleteDisplay(c, save, ch)
+			return 0
+		}
+
+		if !briefingDisplay(c, save, m, ch) {
+			return 0
+		}
+
+		g := c.Start(save, game.DefaultRules(), game.NewRandom(game.NewSeed()))
+		g.TurnBased = turnBased
+		outcome := loop(g, ch)
+		if outcome == game.Playing {
+			// Quit part way through, so the mission is flown
+			// again from the start next time
+			return 0
+		}
+
+		save.Finish(m, g, outcome)
+		saveErr := save.Write(saveFile)
+
+		var carryOn bool
+		if outcome == game.Won {
+			carryOn = playerWinsDisplay(g, m, saveErr, ch)
+		} else {
+			carryOn = playerDeadDisplay(g, outcome, m, saveErr, ch)
+		}
+		if !carryOn {
+			return 0
+		}
+	}
+}
+
+// briefingDisplay shows the mission briefing and objectives,
+// returning false if the player chose to quit
+func briefingDisplay(c *campaign.Campaign, save *campaign.Save, m *campaign.Mission, ch chan tcell.Event) bool {
+	lines := []string{
+		strings.ToUpper(c.Name),
+		game.T("campaign.mission", save.Mission+1, len(c.Missions), m.Name),
+		"",
+	}
+	lines = append(lines, m.Briefing...)
+	if len(m.Objectives) > 0 {
+		lines = append(lines, "", game.T("campaign.objectives"))
+		for _, o := range m.Objectives {
+			lines = append(lines, "- "+o)
+		}
+	}
+	if save.Ship != nil {
+		lines = append(lines, "", game.T("campaign.ship", save.Ship.Energy, save.Ship.Shields(), save.Ship.Torpedoes))
+	}
+
+	displayText(lines, game.T("prompt.begin_or_quit"))
+	return waitForContinue(ch)
+}
+
+// campaignCompleteDisplay shows the record of the whole campaign
+func campaignCompleteDisplay(c *campaign.Campaign, save *campaign.Save, ch chan tcell.Event) {
+	lines := []string{
+		strings.ToUpper(c.Name),
+		"",
+		game.T("campaign.complete"),
+		"",
+	}
+
+	attempts := map[string]int{}
+	for _, r := range save.Log {
+		attempts[r.Mission]++
+	}
+	casualties := 0
+	for _, r := range save.Log {
+		casualties += r.Casualties
+		if r.Won {
+			lines = append(lines, game.TN("campaign.record", attempts[r.Mission], r.Mission, r.Stardates, r.KlingonsDestroyed, attempts[r.Mission]))
+		}
+	}
+	lines = append(lines, "", game.T("campaign.casualties", casualties))
+
+	displayText(lines, game.T("prompt.esc_quit"))
+	waitForEsc(ch)
+}
@@ -0,0 +1,468 @@
+package classic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hculpan/kabtrek/galaxy"
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/quadrant"
+)
+
+// commands lists the commands and the messages saying what they do
+var commands = [][2]string{
+	{"NAV", "classic.help.nav"},
+	{"SRS", "classic.help.srs"},
+	{"LRS", "classic.help.lrs"},
+	{"PHA", "classic.help.pha"},
+	{"TOR", "classic.help.tor"},
+	{"SHE", "classic.help.she"},
+	{"DAM", "classic.help.dam"},
+	{"COM", "classic.help.com"},
+	{"XXX", "classic.help.xxx"},
+}
+
+// maxSettleTicks stops a turn waiting forever for torpedoes
+const maxSettleTicks = 50
+
+// interpreter plays the game as a teletype would, reading
+// commands and printing reports as plain lines of text
+type interpreter struct {
+	g    *galaxy.Galaxy
+	in   *bufio.Scanner
+	out  io.Writer
+	seen int
+}
+
+// Run plays the galaxy with commands read from in and reports
+// written to out, returning the outcome, or game.Playing if
+// the player resigned or the input ran out
+func Run(g *galaxy.Galaxy, in io.Reader, out io.Writer) int {
+	g.Headless = true
+	c := &interpreter{g: g, in: bufio.NewScanner(in), out: out}
+	return c.play()
+}
+
+// play runs the command loop until the game is over
+func (c *interpreter) play() int {
+	c.orders()
+	c.shortRangeScan()
+
+	for {
+		c.printMessages()
+		if outcome := c.g.Outcome(); outcome != game.Playing {
+			c.gameOver(outcome)
+			return outcome
+		}
+
+		line, ok := c.ask(game.T("classic.command"))
+		if !ok {
+			return game.Playing
+		}
+		command := strings.ToUpper(line)
+		if len(command) > 3 {
+			command = command[:3]
+		}
+
+		switch command {
+		case "NAV":
+			c.navigate()
+		case "SRS":
+			c.shortRangeScan()
+		case "LRS":
+			c.longRangeScan()
+		case "PHA":
+			c.phasers()
+		case "TOR":
+			c.torpedo()
+		case "SHE":
+			c.shields()
+		case "DAM":
+			c.damageReport()
+		case "COM":
+			c.computer()
+		case "XXX":
+			c.printf("\n")
+			c.say("classic.left", c.g.NumberOfKlingons)
+			c.say("classic.end_of_mission")
+			return game.Playing
+		case "":
+		default:
+			c.say("classic.enter_one")
+			for _, cmd := range commands {
+				c.printf("  %s  (%s)\n", cmd[0], game.T(cmd[1]))
+			}
+		}
+	}
+}
+
+func (c *interpreter) printf(format string, a ...interface{}) {
+	fmt.Fprintf(c.out, format, a...)
+}
+
+// say prints a message on a line of its own
+func (c *interpreter) say(id string, a ...interface{}) {
+	c.printf("%s\n", game.T(id, a...))
+}
+
+// ask prompts for a line of input, returning false when
+// there is no more
+func (c *interpreter) ask(prompt string) (string, bool) {
+	c.printf("%s? ", prompt)
+	if !c.in.Scan() {
+		c.printf("\n")
+		return "", false
+	}
+	return strings.TrimSpace(c.in.Text()), true
+}
+
+// askNumber prompts for a number, returning false if none
+// was given
+func (c *interpreter) askNumber(prompt string) (float64, bool) {
+	line, ok := c.ask(prompt)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(line, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// printMessages prints whatever has been added to the log
+// since it was last looked at
+func (c *interpreter) printMessages() {
+	var messages []game.Message
+	messages, c.seen = c.g.Log.Since(c.seen)
+	for _, m := range messages {
+		c.printf("%s\n", m.Text)
+	}
+}
+
+// orders gives the player their mission
+func (c *interpreter) orders() {
+	g := c.g
+	c.say("classic.orders")
+	c.say("classic.orders_destroy", g.NumberOfKlingons-g.Conditions.KlingonsRemaining)
+	c.say("classic.orders_galaxy")
+	if g.Conditions.TimeLimit > 0 {
+		c.say("classic.orders_time", g.StartingStardate+g.Conditions.TimeLimit, g.Conditions.TimeLimit)
+	}
+	c.say("classic.orders_starbases", g.NumberOfStarbases)
+	c.printf("\n")
+	c.say("classic.orders_course")
+	c.say("classic.orders_help")
+	c.printf("\n")
+}
+
+// settle runs the game clock until every torpedo has landed,
+// and always for at least one turn
+func (c *interpreter) settle() {
+	c.g.Tick()
+	c.g.Tick()
+	for i := 0; i < maxSettleTicks && c.g.GetActiveQuadrant().TorpedoesInFlight() > 0; i++ {
+		c.g.Tick()
+	}
+}
+
+// direction turns a course into one of the eight directions
+func direction(course float64) (int, bool) {
+	d := int(math.Round(course))
+	if d < 1 || d > 9 || d == quadrant.Dir5 {
+		return 0, false
+	}
+	return d, true
+}
+
+// navigate moves within the quadrant at warp factors below 1,
+// a sector for each tenth, and otherwise warps a quadrant for
+// each whole warp factor
+func (c *interpreter) navigate() {
+	course, ok := c.askNumber(game.T("classic.course"))
+	d, valid := direction(course)
+	if !ok || !valid {
+		c.say("classic.bad_course")
+		return
+	}
+	warp, ok := c.askNumber(game.T("classic.warp"))
+	if !ok || warp <= 0 || warp > 8 {
+		c.say("classic.bad_warp", strconv.FormatFloat(warp, 'f', -1, 64))
+		return
+	}
+
+	g := c.g
+	if warp < 1 {
+		sectors := int(math.Round(warp * 10))
+		if sectors < 1 {
+			sectors = 1
+		}
+		for i := 0; i < sectors; i++ {
+			q := g.GetActiveQuadrant()
+			x, y := q.Player.X, q.Player.Y
+			g.MovePlayer(d)
+			if g.GetActiveQuadrant() != q || g.Outcome() != game.Playing {
+				break
+			}
+			if q.Player.X == x && q.Player.Y == y {
+				c.say("classic.shut_down", x, y)
+				break
+			}
+		}
+	} else {
+		dx, dy := quadrant.NewLocation(0, 0, d)
+		n := int(warp)
+		x := clamp(g.ActiveQuadrantX+dx*n, 0, g.Rules.Galaxy.Width-1)
+		y := clamp(g.ActiveQuadrantY+dy*n, 0, g.Rules.Galaxy.Height-1)
+		if x == g.ActiveQuadrantX && y == g.ActiveQuadrantY {
+			c.say("classic.edge")
+			return
+		}
+		if !g.GetActiveQuadrant().Warp(x, y) {
+			return
+		}
+		c.say("classic.entering", x+1, y+1)
+		c.printMessages()
+		c.shortRangeScan()
+	}
+	c.settle()
+}
+
+func clamp(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+// shortRangeScan prints the sector map with the ship's status beside it
+func (c *interpreter) shortRangeScan() {
+	q := c.g.GetActiveQuadrant()
+	p := q.Player
+	status := []string{
+		game.T("classic.srs.stardate", c.g.Stardate),
+		game.T("classic.srs.condition", game.T("condition."+q.Condition())),
+		game.T("classic.srs.quadrant", q.X+1, q.Y+1),
+		game.T("classic.srs.sector", p.X, p.Y),
+		game.T("classic.srs.torpedoes", p.Torpedoes),
+		game.T("classic.srs.energy", p.Energy+p.Shields()),
+		game.T("classic.srs.shields", p.Shields()),
+		game.T("classic.srs.klingons", c.g.NumberOfKlingons),
+		game.T("classic.srs.crew", p.Crew, p.Morale),
+		game.T("classic.srs.heading", game.T("heading."+quadrant.HeadingNames[p.Heading])),
+	}
+
+	w, h := q.Size.Width, q.Size.Height
+	border := "   " + strings.Repeat("-", w*4)
+	c.printf("%s\n", border)
+	// A short quadrant carries on the status below the map
+	for y := 0; y < game.Max(h, len(status)); y++ {
+		row := strings.Repeat(" ", 3+w*4)
+		if y < h {
+			row = fmt.Sprintf("%2d ", y)
+			for x := 0; x < w; x++ {
+				if s := q.SectorSymbol(x, y); s != "" {
+					row += s + " "
+				} else {
+					row += " .  "
+				}
+			}
+		}
+		line := ""
+		if y < len(status) {
+			line = status[y]
+		}
+		c.printf("%s %s\n", row, line)
+	}
+	c.printf("%s\n", border)
+	labels := " "
+	for x := 0; x < w; x++ {
+		labels += fmt.Sprintf("%4d", x)
+	}
+	c.printf("%s\n", labels)
+}
+
+// longRangeScan prints the quadrants around the Enterprise
+func (c *interpreter) longRangeScan() {
+	g := c.g
+	c.say("classic.lrs", g.ActiveQuadrantX+1, g.ActiveQuadrantY+1)
+	border := strings.Repeat("-", 25)
+	c.printf("%s\n", border)
+	for y := -1; y < 2; y++ {
+		line := ":"
+		for x := -1; x < 2; x++ {
+			line += " " + g.ScanQuadrant(g.ActiveQuadrantX+x, g.ActiveQuadrantY+y) + " :"
+		}
+		c.printf("%s\n%s\n", line, border)
+	}
+}
+
+// phasers fires the phasers with as much energy as asked for
+func (c *interpreter) phasers() {
+	q := c.g.GetActiveQuadrant()
+	c.say("classic.phasers", q.Player.Energy)
+	energy, ok := c.askNumber(game.T("classic.units_fire"))
+	if !ok || energy <= 0 {
+		return
+	}
+	if q.FirePhasers(int(energy)) {
+		c.settle()
+	}
+}
+
+// torpedo fires a photon torpedo
+func (c *interpreter) torpedo() {
+	q := c.g.GetActiveQuadrant()
+	if q.Player.Torpedoes <= 1 {
+		c.say("classic.no_torpedoes")
+		return
+	}
+	course, ok := c.askNumber(game.T("classic.torpedo_course"))
+	d, valid := direction(course)
+	if !ok || !valid {
+		c.say("classic.bad_torpedo_course")
+		return
+	}
+	if q.FireTorpedo(d) {
+		c.settle()
+	}
+}
+
+// shields moves energy to or from the shields
+func (c *interpreter) shields() {
+	q := c.g.GetActiveQuadrant()
+	c.say("classic.energy_available", q.Player.Energy+q.Player.Shields())
+	energy, ok := c.askNumber(game.T("classic.units_shields"))
+	if !ok || energy < 0 {
+		c.say("classic.shields_unchanged")
+		return
+	}
+	q.SetShields(int(energy))
+	c.say("classic.deflector")
+	c.say("classic.shields_now", q.Player.Shields())
+}
+
+// damageReport reports on the state of the ship and crew
+func (c *interpreter) damageReport() {
+	p := c.g.Player
+	c.say("classic.damage")
+	c.say("classic.damage.crew", p.Crew, game.EnterpriseMaxCrew, p.Casualties)
+	c.say("classic.damage.morale", p.Morale)
+	c.say("classic.damage.efficiency", p.Efficiency())
+	c.say("classic.damage.power", p.EnginePower, p.ShieldPower, p.WeaponPower)
+	c.say("classic.damage.shields",
+		p.ShieldArcs[quadrant.ArcFore], p.ShieldArcs[quadrant.ArcStarboard],
+		p.ShieldArcs[quadrant.ArcAft], p.ShieldArcs[quadrant.ArcPort])
+	if p.Energy <= 0 {
+		c.say("classic.damage.life_support", float64(p.LifeSupport)/10)
+	}
+}
+
+// computer offers the library-computer's functions
+func (c *interpreter) computer() {
+	choice, ok := c.askNumber(game.T("classic.computer"))
+	if !ok {
+		choice = -1
+	}
+	switch int(choice) {
+	case 0:
+		c.galacticRecord()
+	case 1:
+		c.statusReport()
+	case 2:
+		c.bearings(game.T("classic.klingon"), quadrant.IsKlingon)
+	case 3:
+		c.bearings(game.T("classic.starbase"), func(o quadrant.Object) bool {
+			_, ok := o.(*quadrant.Starbase)
+			return ok
+		})
+	default:
+		c.say("classic.functions")
+	}
+}
+
+// galacticRecord prints the galaxy map
+func (c *interpreter) galacticRecord() {
+	c.say("classic.record")
+	w, h := c.g.Rules.Galaxy.Width, c.g.Rules.Galaxy.Height
+	labels := " "
+	for x := 0; x < w; x++ {
+		labels += fmt.Sprintf("%7d", x+1)
+	}
+	c.printf("%s\n", labels)
+	border := "    " + strings.Repeat("-", w*7+1)
+	c.printf("%s\n", border)
+	for y := 0; y < h; y++ {
+		row := fmt.Sprintf("%2d  ", y+1)
+		for x := 0; x < w; x++ {
+			row += c.g.MapCell(x, y)
+		}
+		c.printf("%s\n", row)
+	}
+	c.printf("%s\n", border)
+}
+
+// statusReport summarises the war
+func (c *interpreter) statusReport() {
+	g := c.g
+	c.say("classic.status")
+	c.say("classic.klingons_left", g.NumberOfKlingons)
+	if g.Conditions.TimeLimit > 0 {
+		c.say("classic.time_left", g.StartingStardate+g.Conditions.TimeLimit-g.Stardate)
+	}
+	c.say("classic.maintaining", g.NumberOfStarbases)
+}
+
+// bearings gives the direction and distance to every object
+// in the quadrant of the kind asked for
+func (c *interpreter) bearings(name string, kind func(quadrant.Object) bool) {
+	q := c.g.GetActiveQuadrant()
+	found := false
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			o := q.Objects[x][y]
+			if o == nil || !kind(o) || q.SectorSymbol(x, y) == "" {
+				continue
+			}
+			found = true
+			dx, dy := x-q.Player.X, y-q.Player.Y
+			line := game.T("classic.off_line")
+			if dx == 0 || dy == 0 || dx == dy || dx == -dy {
+				line = game.T("classic.in_line")
+			}
+			c.say("classic.bearing", name, x, y, quadrant.DirectionTo(q.Player.X, q.Player.Y, x, y),
+				game.Distance(q.Player.X, q.Player.Y, x, y), line)
+		}
+	}
+	if !found {
+		c.say("classic.none_found", name)
+	}
+}
+
+// gameOver prints the end of the game
+func (c *interpreter) gameOver(outcome int) {
+	g := c.g
+	c.printf("\n")
+	c.say("classic.stardate", g.Stardate)
+	switch outcome {
+	case game.Won:
+		c.say("classic.won")
+	case game.OutOfTime:
+		c.say("classic.out_of_time")
+	case game.StarbasesLost:
+		c.say("classic.starbases_lost", g.NumberOfStarbases)
+	default:
+		c.say("classic.destroyed")
+	}
+	if outcome != game.Won {
+		c.say("classic.left", g.NumberOfKlingons)
+		c.say("classic.end_of_mission")
+	}
+}
@@ -0,0 +1,129 @@
+package galaxy
+
+import (
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/quadrant"
+)
+
+type commanderMove struct {
+	from *quadrant.Quadrant
+	ship quadrant.MoveableObject
+	x, y int
+}
+
+// roamCommanders moves the Commanders and Super-Commander a
+// quadrant across the galaxy map.  Commanders make for the
+// nearest starbase and lay siege to it, while the
+// Super-Commander makes for the Enterprise.  Anything in
+// the Enterprise's quadrant stays to fight.
+func (g *Galaxy) roamCommanders() {
+	var moves []commanderMove
+	for qx := range g.Quadrants {
+		for qy := range g.Quadrants[qx] {
+			if qx == g.ActiveQuadrantX && qy == g.ActiveQuadrantY {
+				continue
+			}
+
+			q := &g.Quadrants[qx][qy]
+			for _, o := range commandersIn(q) {
+				switch o.(type) {
+				case *quadrant.Commander:
+					if q.NumberOfStarbases > 0 {
+						g.siegeStarbase(q)
+						continue
+					}
+					tx, ty, ok := g.nearestStarbaseQuadrant(qx, qy)
+					if !ok {
+						tx, ty = qx+g.Random.RandomInt(3)-1, qy+g.Random.RandomInt(3)-1
+					}
+					moves = append(moves, commanderMove{from: q, ship: o, x: tx, y: ty})
+				case *quadrant.SuperCommander:
+					moves = append(moves, commanderMove{from: q, ship: o, x: g.ActiveQuadrantX, y: g.ActiveQuadrantY})
+				}
+			}
+		}
+	}
+
+	for _, m := range moves {
+		nx := m.from.X + sign(m.x-m.from.X)
+		ny := m.from.Y + sign(m.y-m.from.Y)
+		if to := g.getQuadrant(nx, ny); to != nil {
+			g.moveCommander(m.from, to, m.ship)
+		}
+	}
+}
+
+// commandersFollow gives any Commanders in the quadrant the
+// Enterprise has just left a chance to follow it
+func (g *Galaxy) commandersFollow(from *quadrant.Quadrant) {
+	to := g.GetActiveQuadrant()
+	for _, o := range commandersIn(from) {
+		if _, super := o.(*quadrant.SuperCommander); super || g.Random.CheckPercent(g.Rules.CommanderFollowPercent) {
+			g.moveCommander(from, to, o)
+		}
+	}
+}
+
+func (g *Galaxy) moveCommander(from, to *quadrant.Quadrant, o quadrant.MoveableObject) {
+	if from == to || to.Supernova {
+		return
+	}
+	if to.EmptySectors() == 0 {
+		return
+	}
+	from.RemoveObject(o)
+	to.EnterObject(o)
+	if to == g.GetActiveQuadrant() {
+		x, y := o.Location()
+		to.AddAlert(game.T("alert.entered", o.Name(), x, y))
+	}
+}
+
+func (g *Galaxy) siegeStarbase(q *quadrant.Quadrant) {
+	found, destroyed := q.SiegeStarbase(g.Rules.CommanderBaseDamage)
+	if destroyed {
+		g.GetActiveQuadrant().AddAlert(game.T("alert.starbase_destroyed", q.X+1, q.Y+1))
+	} else if found {
+		g.GetActiveQuadrant().AddAlert(game.T("alert.starbase_attack", q.X+1, q.Y+1))
+	}
+}
+
+func (g *Galaxy) nearestStarbaseQuadrant(qx, qy int) (int, int, bool) {
+	bestX, bestY, best := 0, 0, -1.0
+	for x := range g.Quadrants {
+		for y := range g.Quadrants[x] {
+			if g.Quadrants[x][y].NumberOfStarbases == 0 {
+				continue
+			}
+			if d := game.Distance(qx, qy, x, y); best < 0 || d < best {
+				bestX, bestY, best = x, y, d
+			}
+		}
+	}
+	return bestX, bestY, best >= 0
+}
+
+func commandersIn(q *quadrant.Quadrant) []quadrant.MoveableObject {
+	var result []quadrant.MoveableObject
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			switch o := q.Objects[x][y].(type) {
+			case *quadrant.Commander:
+				result = append(result, o)
+			case *quadrant.SuperCommander:
+				result = append(result, o)
+			}
+		}
+	}
+	return result
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
@@ -2,6 +2,7 @@ package galaxy
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hculpan/kabtrek/game"
 	"github.com/hculpan/kabtrek/quadrant"
@@ -10,112 +11,307 @@ import (
 // Galaxy contains info for, well, the galaxy
 type Galaxy struct {
 	Stardate                  float64
+	StartingStardate          float64
 	StartingNumberOfKlingons  int
 	StartingNumberOfStarbases int
 	NumberOfKlingons          int
 	NumberOfStarbases         int
-	Quadrants                 [8][8]quadrant.Quadrant
-	Player                    *quadrant.Enterprise
+
+	StartingNumberOfCommanders      int
+	NumberOfCommanders              int
+	StartingNumberOfSuperCommanders int
+	NumberOfSuperCommanders         int
+
+	Quadrants [][]quadrant.Quadrant
+	Player    *quadrant.Enterprise
+	Probes    []*Probe
 
 	ActiveQuadrantX int
 	ActiveQuadrantY int
 	GameState       int
+
+	Rules      *game.Rules
+	Random     *game.Random
+	Conditions game.Conditions
+	Log        *game.Log
+
+	// Headless galaxies never touch the screen
+	Headless bool
+
+	// TurnBased galaxies only move on when the player gives
+	// an order, rather than with the clock
+	TurnBased bool
+
+	// Clock counts the game time, and the stardate follows it
+	Clock *game.Clock
 }
 
 // NewGalaxy create a whole new galaxy
 func NewGalaxy(numKlingons, numStarbases int) *Galaxy {
-	result := &Galaxy{
+	return NewGalaxyWithRules(numKlingons, numStarbases, game.DefaultRules(), game.NewRandom(game.NewSeed()))
+}
+
+// NewEmptyGalaxy creates a galaxy of empty quadrants, ready
+// to be filled in by hand
+func NewEmptyGalaxy(numKlingons, numStarbases int, rules *game.Rules, rnd *game.Random) *Galaxy {
+	result := newGalaxy(numKlingons, numStarbases, rules, rnd)
+	for x := range result.Quadrants {
+		for y := range result.Quadrants[x] {
+			result.Quadrants[x][y] = *quadrant.NewQuadrant(result, x, y, 0, 0, 0)
+		}
+	}
+	return result
+}
+
+func newGalaxy(numKlingons, numStarbases int, rules *game.Rules, rnd *game.Random) *Galaxy {
+	quadrants := make([][]quadrant.Quadrant, rules.Galaxy.Width)
+	for x := range quadrants {
+		quadrants[x] = make([]quadrant.Quadrant, rules.Galaxy.Height)
+	}
+	return &Galaxy{
 		Stardate:                  3700.1,
+		StartingStardate:          3700.1,
 		StartingNumberOfKlingons:  numKlingons,
 		StartingNumberOfStarbases: numStarbases,
 		NumberOfKlingons:          numKlingons,
 		NumberOfStarbases:         numStarbases,
-		Quadrants:                 [8][8]quadrant.Quadrant{},
+		Quadrants:                 quadrants,
 		GameState:                 game.Quadrant,
+		Rules:                     rules,
+		Random:                    rnd,
+		Log:                       game.NewLog(),
+		Clock:                     game.NewClock(),
 	}
+}
 
-	quadsGened := [8][8]bool{}
-	for x := 0; x < 8; x++ {
-		for y := 0; y < 8; y++ {
-			quadsGened[x][y] = false
-		}
+// NewGalaxyWithRules creates a whole new galaxy using the
+// given rules and random source
+func NewGalaxyWithRules(numKlingons, numStarbases int, rules *game.Rules, rnd *game.Random) *Galaxy {
+	result := newGalaxy(numKlingons, numStarbases, rules, rnd)
+
+	w, h := rules.Galaxy.Width, rules.Galaxy.Height
+	quadsGened := make([][]bool, w)
+	for x := range quadsGened {
+		quadsGened[x] = make([]bool, h)
 	}
+	ungenerated := w * h
 
 	remainingKlingons := numKlingons
 	remainingStarbases := numStarbases
 	for remainingKlingons > 0 {
 		// Pick number of klingons to place
-		numKlingonsInQuadrant := selectKlingonsForQuadrant()
+		numKlingonsInQuadrant := rules.KlingonsForQuadrant(rnd)
 		if numKlingonsInQuadrant > remainingKlingons {
 			numKlingonsInQuadrant = remainingKlingons
 		}
 		remainingKlingons -= numKlingonsInQuadrant
 
+		// Once every quadrant has some, the rest join them, for
+		// as long as there is room, keeping a sector free for
+		// the Enterprise
+		if ungenerated == 0 {
+			q := result.quadrantWithRoom(1)
+			if q == nil {
+				remainingKlingons += numKlingonsInQuadrant
+				break
+			}
+			for i := 0; i < numKlingonsInQuadrant; i++ {
+				x, y, ok := q.RandomEmptySector()
+				if !ok || result.emptySectors() <= 1 {
+					remainingKlingons += numKlingonsInQuadrant - i
+					break
+				}
+				q.AddObject(quadrant.NewKlingon(x, y))
+			}
+			continue
+		}
+
 		for {
-			x := game.RandomInt(8)
-			y := game.RandomInt(8)
-			if !quadsGened[x][y] && remainingStarbases > 0 && game.CheckPercent(10) {
-				result.Quadrants[x][y] = *quadrant.NewQuadrant(result, x, y, numKlingonsInQuadrant, game.RandomInt(7), 1)
+			x := rnd.RandomInt(w)
+			y := rnd.RandomInt(h)
+			if !quadsGened[x][y] && remainingStarbases > 0 && rnd.CheckPercent(10) {
+				result.Quadrants[x][y] = *quadrant.NewQuadrant(result, x, y, numKlingonsInQuadrant, rnd.RandomInt(7), 1)
 				remainingStarbases--
 				quadsGened[x][y] = true
+				ungenerated--
 				break
 			} else if !quadsGened[x][y] {
-				result.Quadrants[x][y] = *quadrant.NewQuadrant(result, x, y, numKlingonsInQuadrant, game.RandomInt(7), 0)
+				result.Quadrants[x][y] = *quadrant.NewQuadrant(result, x, y, numKlingonsInQuadrant, rnd.RandomInt(7), 0)
 				quadsGened[x][y] = true
+				ungenerated--
 				break
 			}
 		}
 
 	}
 
-	for remainingStarbases > 0 {
-		x := game.RandomInt(8)
-		y := game.RandomInt(8)
+	for remainingStarbases > 0 && ungenerated > 0 {
+		x := rnd.RandomInt(w)
+		y := rnd.RandomInt(h)
 		if !quadsGened[x][y] {
-			result.Quadrants[x][y] = *quadrant.NewQuadrant(result, x, y, 0, game.RandomInt(7), 1)
+			result.Quadrants[x][y] = *quadrant.NewQuadrant(result, x, y, 0, rnd.RandomInt(7), 1)
 			remainingStarbases--
 			quadsGened[x][y] = true
+			ungenerated--
 		}
 	}
-
-	for x := 0; x < 8; x++ {
-		for y := 0; y < 8; y++ {
+	// A small galaxy may not have room for them all
+	result.StartingNumberOfKlingons -= remainingKlingons
+	result.NumberOfKlingons -= remainingKlingons
+	result.StartingNumberOfStarbases -= remainingStarbases
+	result.NumberOfStarbases -= remainingStarbases
+
+	for x := range quadsGened {
+		for y := range quadsGened[x] {
 			if !quadsGened[x][y] {
-				result.Quadrants[x][y] = *quadrant.NewQuadrant(result, x, y, 0, game.RandomInt(7), 0)
+				result.Quadrants[x][y] = *quadrant.NewQuadrant(result, x, y, 0, rnd.RandomInt(7), 0)
 			}
 		}
 	}
 
+	for i := 0; i < rules.Romulans; i++ {
+		q := result.quadrantWithRoom(1)
+		if q == nil {
+			break
+		}
+		x, y, _ := q.RandomEmptySector()
+		q.AddObject(quadrant.NewRomulan(x, y))
+	}
+
+	classes := []string{quadrant.ClassM, quadrant.ClassN, quadrant.ClassO}
+	for i := 0; i < rules.Planets; i++ {
+		q := result.quadrantWithRoom(1)
+		if q == nil {
+			break
+		}
+		x, y, _ := q.RandomEmptySector()
+		q.AddObject(quadrant.NewPlanet(x, y, classes[rnd.RandomInt(len(classes))], rnd.RandomInt(6)))
+	}
+
+	for i := 0; i < rules.BlackHoles; i++ {
+		q := result.quadrantWithRoom(1)
+		if q == nil {
+			break
+		}
+		x, y, _ := q.RandomEmptySector()
+		q.AddObject(&quadrant.BlackHole{X: x, Y: y})
+	}
+	for i := 0; i < rules.Wormholes && result.emptySectors() > 2; i++ {
+		result.AddWormholes(rnd.RandomInt(w), rnd.RandomInt(h), rnd.RandomInt(w), rnd.RandomInt(h))
+	}
+
+	for i := 0; i < rules.Commanders; i++ {
+		q := result.quadrantWithRoom(1)
+		if q == nil {
+			break
+		}
+		x, y, _ := q.RandomEmptySector()
+		result.AddCommander(q, quadrant.NewCommander(x, y))
+	}
+	for i := 0; i < rules.SuperCommanders; i++ {
+		q := result.quadrantWithRoom(1)
+		if q == nil {
+			break
+		}
+		x, y, _ := q.RandomEmptySector()
+		result.AddCommander(q, quadrant.NewSuperCommander(x, y))
+	}
+
 	return result
 }
 
-func selectKlingonsForQuadrant() int {
-	n := game.GetPercent()
-	switch {
-	case n < 55:
-		return 0
-	case n < 75:
-		return 1
-	case n < 85:
-		return 2
-	case n < 92:
-		return 3
-	case n < 97:
-		return 4
-	default:
-		return 5
+// quadrantWithRoom picks a random quadrant with an empty
+// sector, so long as more than spare sectors are left empty
+// across the galaxy, or returns nil if there are not
+func (g *Galaxy) quadrantWithRoom(spare int) *quadrant.Quadrant {
+	w, h := g.Rules.Galaxy.Width, g.Rules.Galaxy.Height
+	if q := &g.Quadrants[g.Random.RandomInt(w)][g.Random.RandomInt(h)]; q.EmptySectors() > spare {
+		return q
+	}
+	var roomy []*quadrant.Quadrant
+	empty := 0
+	for x := range g.Quadrants {
+		for y := range g.Quadrants[x] {
+			if n := g.Quadrants[x][y].EmptySectors(); n > 0 {
+				roomy = append(roomy, &g.Quadrants[x][y])
+				empty += n
+			}
+		}
+	}
+	if empty <= spare {
+		return nil
 	}
+	return roomy[g.Random.RandomInt(len(roomy))]
+}
+
+// emptySectors counts the empty sectors across the galaxy
+func (g *Galaxy) emptySectors() int {
+	result := 0
+	for x := range g.Quadrants {
+		for y := range g.Quadrants[x] {
+			result += g.Quadrants[x][y].EmptySectors()
+		}
+	}
+	return result
+}
+
+// AddCommander places a Commander or Super-Commander in
+// the quadrant, counting it as one of the Klingons
+func (g *Galaxy) AddCommander(q *quadrant.Quadrant, o quadrant.Object) {
+	q.AddObject(o)
+	g.StartingNumberOfKlingons++
+	g.NumberOfKlingons++
+	switch o.(type) {
+	case *quadrant.Commander:
+		g.StartingNumberOfCommanders++
+		g.NumberOfCommanders++
+	case *quadrant.SuperCommander:
+		g.StartingNumberOfSuperCommanders++
+		g.NumberOfSuperCommanders++
+	}
+}
+
+// AddWormholes links two quadrants with a pair of wormholes
+// in random sectors, so long as there is room for them
+func (g *Galaxy) AddWormholes(qx1, qy1, qx2, qy2 int) {
+	q1, q2 := &g.Quadrants[qx1][qy1], &g.Quadrants[qx2][qy2]
+	if q1.EmptySectors() == 0 || q2.EmptySectors() == 0 || (q1 == q2 && q1.EmptySectors() < 2) {
+		return
+	}
+	x1, y1, _ := q1.RandomEmptySector()
+	w1 := &quadrant.Wormhole{X: x1, Y: y1}
+	q1.AddObject(w1)
+	x2, y2, _ := q2.RandomEmptySector()
+	g.LinkWormholes(w1, qx1, qy1, x2, y2, qx2, qy2)
+}
+
+// LinkWormholes adds a partner for the wormhole at the given
+// sector and quadrant
+func (g *Galaxy) LinkWormholes(w *quadrant.Wormhole, qx1, qy1, x2, y2, qx2, qy2 int) {
+	w2 := &quadrant.Wormhole{
+		X:                x2,
+		Y:                y2,
+		PartnerQuadrantX: qx1,
+		PartnerQuadrantY: qy1,
+		PartnerX:         w.X,
+		PartnerY:         w.Y,
+		Partner:          &g.Quadrants[qx1][qy1],
+	}
+	g.Quadrants[qx2][qy2].AddObject(w2)
+	w.PartnerQuadrantX, w.PartnerQuadrantY = qx2, qy2
+	w.PartnerX, w.PartnerY = x2, y2
+	w.Partner = &g.Quadrants[qx2][qy2]
 }
 
 func (g *Galaxy) getQuadrant(x, y int) *quadrant.Quadrant {
-	if x >= 0 && x < 8 && y >= 0 && y < 8 {
+	if g.Rules.Galaxy.Contains(x, y) {
 		return &g.Quadrants[x][y]
 	}
 	return nil
 }
 
-// ScanNeighborQuadrants reveals all quadrants
-// adjacent to active quadrant
+// ScanNeighborQuadrants records what the long-range
+// sensors see of the quadrants around the active quadrant
 func (g *Galaxy) ScanNeighborQuadrants() {
 	qx := g.ActiveQuadrantX
 	qy := g.ActiveQuadrantY
@@ -123,20 +319,123 @@ func (g *Galaxy) ScanNeighborQuadrants() {
 		for y := -1; y < 2; y++ {
 			q := g.getQuadrant(x+qx, y+qy)
 			if q != nil {
-				q.Scanned = true
+				q.Scan(g.Stardate)
 			}
 		}
 	}
 }
 
-// Update runs the update cycle for the galaxy and
-// any quadrants
+// Update moves the clock on by a turn and runs the update
+// cycle for the galaxy and any quadrants
 func (g *Galaxy) Update() {
 	if g.GameState == game.Quadrant {
-		g.Stardate += 0.1
+		g.Clock.EndTurn()
+		g.turn()
+	}
+}
+
+// turn runs the update cycle for the turn the clock has
+// just finished
+func (g *Galaxy) turn() {
+	g.Stardate = g.StartingStardate + g.Clock.Stardates()
 
-		g.GetActiveQuadrant().Update()
+	g.GetActiveQuadrant().Update()
+	g.moveProbes()
+
+	if g.Clock.Turns()%game.TurnsPerStardate == 0 {
+		g.roamCommanders()
+		g.supernova()
+	}
+}
+
+// Outcome checks the win and lose conditions, returning
+// game.Playing while the game is still going
+func (g *Galaxy) Outcome() int {
+	switch {
+	case g.GetActiveQuadrant().IsPlayerDead():
+		return game.PlayerDestroyed
+	case g.NumberOfKlingons <= g.Conditions.KlingonsRemaining:
+		return game.Won
+	case g.NumberOfStarbases < g.Conditions.MinimumStarbases:
+		return game.StarbasesLost
+	case g.Conditions.TimeLimit > 0 && g.Stardate-g.StartingStardate >= g.Conditions.TimeLimit:
+		return game.OutOfTime
+	}
+	return game.Playing
+}
+
+// Tick advances the galaxy by one tick of the game clock:
+// torpedoes move every tick and the galaxy updates at the
+// end of each turn.  The clock stands still while the
+// Enterprise isn't in a quadrant.
+func (g *Galaxy) Tick() {
+	g.GetActiveQuadrant().UpdateTorpedoes()
+	if g.GameState == game.Quadrant && g.Clock.Tick() {
+		g.turn()
+	}
+}
+
+// MovePlayer moves the Enterprise one sector in the given
+// direction, which uses up a turn
+func (g *Galaxy) MovePlayer(direction int) {
+	q := g.GetActiveQuadrant()
+	if !q.EnginesReady() || !q.LeaveOrbit() {
+		return
+	}
+	if direction != quadrant.Dir5 {
+		q.Player.Heading = direction
 	}
+	q.MoveObject(q.Player, direction)
+	g.Spend(game.ActionMove)
+}
+
+// Spend lets the time an order takes pass.  In real time the
+// clock keeps the game going, so only moving and warping take
+// a turn of their own.  In turn-based play every order takes
+// the turns the rules give it, and each torpedo lands before
+// anything else happens.
+func (g *Galaxy) Spend(action int) {
+	if !g.TurnBased {
+		if action == game.ActionMove || action == game.ActionWarp {
+			g.Update()
+		}
+		return
+	}
+	g.settleTorpedoes()
+	for i := 0; i < g.Rules.ActionTurns[action] && g.Outcome() == game.Playing; i++ {
+		g.Update()
+		g.settleTorpedoes()
+	}
+}
+
+// maxTorpedoSteps stops a torpedo that never lands from
+// holding up the game
+const maxTorpedoSteps = 50
+
+// settleTorpedoes flies every torpedo in the active quadrant
+// to wherever it ends up
+func (g *Galaxy) settleTorpedoes() {
+	q := g.GetActiveQuadrant()
+	for i := 0; i < maxTorpedoSteps && q.TorpedoesInFlight() > 0; i++ {
+		q.UpdateTorpedoes()
+	}
+}
+
+// PlacePlayer puts a new Enterprise in a random quadrant
+// and scans the quadrants around it
+func (g *Galaxy) PlacePlayer() {
+	g.Player = quadrant.NewEnterprise(g.Random.RandomInt(g.Rules.Quadrant.Width), g.Random.RandomInt(g.Rules.Quadrant.Height))
+	g.Player.QuadrantX = g.Random.RandomInt(g.Rules.Galaxy.Width)
+	g.Player.QuadrantY = g.Random.RandomInt(g.Rules.Galaxy.Height)
+	if g.Quadrants[g.Player.QuadrantX][g.Player.QuadrantY].EmptySectors() == 0 {
+		q := g.quadrantWithRoom(0)
+		g.Player.QuadrantX, g.Player.QuadrantY = q.X, q.Y
+	}
+	g.SetActiveQuadrant(g.Player.QuadrantX, g.Player.QuadrantY)
+	// A Romulan right beside the Enterprise could destroy it
+	// before the captain has had a chance to do anything
+	g.GetActiveQuadrant().MoveRomulansAway(g.Player.X, g.Player.Y)
+	g.ScanNeighborQuadrants()
 }
 
 // GetActiveQuadrant returns the active quadrant
@@ -144,13 +443,74 @@ func (g *Galaxy) GetActiveQuadrant() *quadrant.Quadrant {
 	return &g.Quadrants[g.ActiveQuadrantX][g.ActiveQuadrantY]
 }
 
-// SetActiveQuadrant sets the active quadrant
+// SetActiveQuadrant sets the active quadrant, placing
+// the player in a random empty sector
 func (g *Galaxy) SetActiveQuadrant(qx, qy int) {
-	// First clear player from existing quadrant
+	q := g.enterQuadrant(qx, qy)
+	x, y, _ := q.RandomEmptySector()
+	q.Player.X = x
+	q.Player.Y = y
+	q.Objects[x][y] = q.Player
+}
+
+// SetActiveQuadrantAt sets the active quadrant, placing
+// the player at the given sector
+func (g *Galaxy) SetActiveQuadrantAt(qx, qy, sx, sy int) {
+	q := g.enterQuadrant(qx, qy)
+	q.Player.X = sx
+	q.Player.Y = sy
+	q.Objects[sx][sy] = q.Player
+}
+
+// SetActiveQuadrantNear sets the active quadrant, placing the
+// player at the given sector or, if it is taken, as near to it
+// as there is room
+func (g *Galaxy) SetActiveQuadrantNear(qx, qy, sx, sy int) {
+	q := g.enterQuadrant(qx, qy)
+	if q.Objects[sx][sy] != nil {
+		var ok bool
+		if sx, sy, ok = q.EmptyNeighbour(sx, sy); !ok {
+			// Warp and the wormholes keep the Enterprise out
+			// of a full quadrant, so there is room somewhere
+			sx, sy, _ = q.RandomEmptySector()
+		}
+	}
+	q.Player.X = sx
+	q.Player.Y = sy
+	q.Objects[sx][sy] = q.Player
+}
+
+// arrivalSector returns the sector the Enterprise comes in at
+// when it warps from the active quadrant to quadrant x, y: on
+// the edge facing the way it came, in line with where it left
+func (g *Galaxy) arrivalSector(x, y int) (int, int) {
+	p := g.Player
+	return arrivalEdge(x-g.ActiveQuadrantX, p.X, g.Rules.Quadrant.Width-1),
+		arrivalEdge(y-g.ActiveQuadrantY, p.Y, g.Rules.Quadrant.Height-1)
+}
+
+func arrivalEdge(travel, from, last int) int {
+	switch {
+	case travel > 0:
+		return 0
+	case travel < 0:
+		return last
+	}
+	return from
+}
+
+func (g *Galaxy) enterQuadrant(qx, qy int) *quadrant.Quadrant {
+	// First clear player from existing quadrant, remembering
+	// it as it was when the Enterprise left
 	q := g.GetActiveQuadrant()
+	moving := qx != g.ActiveQuadrantX || qy != g.ActiveQuadrantY
 	if q != nil {
-		for x := 0; x < 10; x++ {
-			for y := 0; y < 10; y++ {
+		if q.Player != nil && moving {
+			q.Scan(g.Stardate)
+			q.Departed = g.Stardate
+		}
+		for x := range q.Objects {
+			for y := range q.Objects[x] {
 				switch q.Objects[x][y].(type) {
 				case quadrant.Player:
 					q.Objects[x][y] = nil
@@ -166,64 +526,101 @@ func (g *Galaxy) SetActiveQuadrant(qx, qy int) {
 	if q == nil {
 		panic(fmt.Sprintf("Unable to find quadrant %d, %d", qx, qy))
 	}
-	q.Scanned = true
+	if moving {
+		q.CatchUp(g.Stardate)
+	}
+	q.Scan(g.Stardate)
 	q.Player = g.Player
-	for {
-		x := game.RandomInt(10)
-		y := game.RandomInt(10)
-		if q.Objects[x][y] == nil {
-			q.Player.X = x
-			q.Player.Y = y
-			q.Objects[x][y] = q.Player
-			break
+	q.Player.QuadrantX, q.Player.QuadrantY = qx, qy
+	return q
+}
+
+// drawGalaxyMap draws the galaxy map centred in the region
+func (g *Galaxy) drawGalaxyMap(screen game.Region) {
+	r := g.galaxyMapGrid(screen)
+	w, h := g.Rules.Galaxy.Width, g.Rules.Galaxy.Height
+	border := " " + strings.Repeat("-", w*7+1)
+	r.EmitCentred(0, game.T("galaxy.title"))
+	r.Emit(0, 1, border)
+	for yq := 0; yq < h; yq++ {
+		r.Emit(0, yq+2, "|"+strings.Repeat(" ", w*7+1)+"|")
+		for xq := 0; xq < w; xq++ {
+			lx := (xq * 7) + 1
+			ly := yq + 2
+			r.Emit(lx, ly, g.MapCell(xq, yq))
 		}
 	}
+	r.Emit(0, h+2, border)
+	msg := game.T("galaxy.summary", g.Stardate, g.NumberOfKlingons, g.NumberOfStarbases)
+	screen.EmitCentred(h+3, msg)
+	for i, l := range game.Lines("galaxy.legend", g.Rules.StaleScan) {
+		screen.EmitCentred(h+4+i, l)
+	}
 }
 
-func (g *Galaxy) drawGalaxyMap() {
-	x := 2
-	y := 1
-	game.EmitStr(x, y-1, "                     GALAXY MAP")
-	game.EmitStr(x, y, " -------------------------------------------------")
-	for yq := 0; yq < 8; yq++ {
-		game.EmitStr(x, y+yq+1, "|                                                 |")
-		for xq := 0; xq < 8; xq++ {
-			lx := x + (xq * 6) + 1
-			ly := y + yq + 1
-			s := g.GetQuadrantSummary(xq, yq)
-			if s.IsActive {
-				game.EmitStr(lx, ly, fmt.Sprintf(" *%d%d%d*", s.Klingons, s.Starbases, s.Stars))
-			} else if s.Scanned {
-				game.EmitStr(lx, ly, fmt.Sprintf("  %d%d%d ", s.Klingons, s.Starbases, s.Stars))
-			} else {
-				game.EmitStr(lx, ly, "  ??? ")
-			}
-		}
+// MapCell returns the seven characters the galaxy map shows
+// for a quadrant, marking old readings with a ? and probes
+// with < and >
+func (g *Galaxy) MapCell(x, y int) string {
+	s := g.GetQuadrantSummary(x, y)
+	inner := "?????"
+	switch {
+	case s.Supernova:
+		inner = "*****"
+	case s.Scanned:
+		inner = summaryDigits(s)
+	}
+
+	switch {
+	case s.IsActive && !s.Supernova:
+		return "*" + inner + "*"
+	case g.probeAt(x, y):
+		return "<" + inner + ">"
+	case s.Stale && !s.Supernova:
+		return " " + inner + "?"
 	}
-	game.EmitStr(x, y+9, " -------------------------------------------------")
-	msg := fmt.Sprintf("STARDATE: %.1f     KLINGONS: %d     STARBASES: %d", g.Stardate, g.NumberOfKlingons, g.GetActiveQuadrant().NumberOfKlingons)
-	game.EmitStr(2, y+11, msg)
+	return " " + inner + " "
 }
 
-func (g *Galaxy) drawLongRangeSensors() {
+// galaxyMapGrid returns where the galaxy map's grid of
+// quadrants is drawn in the region
+func (g *Galaxy) galaxyMapGrid(screen game.Region) game.Region {
+	return screen.Centre(g.Rules.Galaxy.Width*7+3, screen.Height)
+}
+
+// summaryDigits gives the five digit code for a quadrant
+func summaryDigits(s *game.QuadrantSummary) string {
+	return fmt.Sprintf("%d%d%d%d%d", s.Klingons, s.Romulans, s.Starbases, s.Planets, s.Stars)
+}
+
+// ScanQuadrant reads a quadrant with the long-range sensors,
+// returning the five characters the scan shows for it
+func (g *Galaxy) ScanQuadrant(x, y int) string {
+	q := g.getQuadrant(x, y)
+	switch {
+	case q == nil:
+		return " *** "
+	case q.Supernova:
+		return "*****"
+	}
+	q.Scan(g.Stardate)
+	return summaryDigits(g.GetQuadrantSummary(x, y))
+}
+
+// drawLongRangeSensors scans the neighbouring quadrants and
+// draws the results in the region
+func (g *Galaxy) drawLongRangeSensors(r game.Region) {
 	g.ScanNeighborQuadrants()
 
-	xloc := 25
-	yloc := 8
 	xq, yq := g.ActiveQuadrantX, g.ActiveQuadrantY
 	for x := -1; x < 2; x++ {
 		for y := -1; y < 2; y++ {
-			q := g.getQuadrant(xq+x, yq+y)
-			game.EmitStr(xloc+(x*6), yloc+(y*4), "------")
-			game.EmitStr(xloc+(x*6), yloc+(y*4)+1, "|     |")
-			if q != nil {
-				q.Scanned = true
-				game.EmitStr(xloc+(x*6), yloc+(y*4)+2, fmt.Sprintf("| %d%d%d |", q.NumberOfKlingons, q.NumberOfStarbases, q.NumberOfStars))
-			} else {
-				game.EmitStr(xloc+(x*6), yloc+(y*4)+2, "| *** |")
-			}
-			game.EmitStr(xloc+(x*6), yloc+(y*4)+3, "|     |")
-			game.EmitStr(xloc+(x*6), yloc+(y*4)+4, "------")
+			xloc, yloc := (x+1)*6, (y+1)*4
+			r.Emit(xloc, yloc, "------")
+			r.Emit(xloc, yloc+1, "|     |")
+			r.Emit(xloc, yloc+2, "|"+g.ScanQuadrant(xq+x, yq+y)+"|")
+			r.Emit(xloc, yloc+3, "|     |")
+			r.Emit(xloc, yloc+4, "------")
 		}
 	}
 }
@@ -234,28 +631,78 @@ func (g *Galaxy) drawLongRangeSensors() {
 
 // NavigateTo the specified quadrant
 func (g *Galaxy) NavigateTo(x, y int) {
-	if x >= 0 && x <= 8 && y >= 0 && y <= 8 {
-		g.SetActiveQuadrant(x, y)
-		g.Update()
+	if g.Rules.Galaxy.Contains(x, y) && !g.Quadrants[x][y].Supernova {
+		from := g.GetActiveQuadrant()
+		sx, sy := g.arrivalSector(x, y)
+		g.SetActiveQuadrantNear(x, y, sx, sy)
+		g.commandersFollow(from)
+		if g.Random.CheckPercent(g.Rules.TholianPercent) {
+			g.GetActiveQuadrant().AddTholian()
+		}
+		g.Spend(game.ActionWarp)
 		g.Draw()
 	}
 }
 
+// supernova sometimes sets off a star in a quadrant away
+// from the Enterprise, destroying everything in it
+func (g *Galaxy) supernova() {
+	if !g.Random.CheckPercent(g.Rules.SupernovaPercent) {
+		return
+	}
+	qx, qy := g.Random.RandomInt(g.Rules.Galaxy.Width), g.Random.RandomInt(g.Rules.Galaxy.Height)
+	q := &g.Quadrants[qx][qy]
+	if q.Supernova || q.NumberOfStars == 0 || (qx == g.ActiveQuadrantX && qy == g.ActiveQuadrantY) {
+		return
+	}
+	q.GoSupernova()
+	q.Scanned = true
+	g.GetActiveQuadrant().AddAlert(game.T("alert.supernova", qx+1, qy+1))
+}
+
+// WormholeTo takes the Enterprise through a wormhole to the
+// given quadrant, arriving next to the partner wormhole
+func (g *Galaxy) WormholeTo(qx, qy, sx, sy int) {
+	q := g.getQuadrant(qx, qy)
+	if q == nil {
+		return
+	}
+	if q.Supernova {
+		g.GetActiveQuadrant().AddAlert(game.T("alert.wormhole_supernova"))
+		return
+	}
+	if q.EmptySectors() == 0 {
+		g.GetActiveQuadrant().AddAlert(game.T("alert.wormhole_full"))
+		return
+	}
+	g.SetActiveQuadrantNear(qx, qy, sx, sy)
+	g.ScanNeighborQuadrants()
+	q.AddMessage(game.T("msg.wormhole", qx+1, qy+1))
+}
+
 func (g *Galaxy) quitting() {
-	w, h := game.Size()
-	msg := "Do you wish to quit (Y/N)?"
-	game.EmitStr(w/2-len(msg)/2, h/2, msg)
+	screen := game.CurrentLayout().Screen
+	screen.EmitCentred(screen.Height/2, game.T("prompt.quit"))
 }
 
 // Draw draw's the quadrant
 func (g *Galaxy) Draw() {
+	if g.Headless {
+		return
+	}
+	if game.TooSmall() {
+		game.DrawTooSmall()
+		return
+	}
+
 	game.ClearScreen()
 
+	l := game.CurrentLayout()
 	switch g.GameState {
 	case game.GalaxyMap:
-		g.drawGalaxyMap()
+		g.drawGalaxyMap(l.Galaxy)
 	case game.LongRangeSensors:
-		g.drawLongRangeSensors()
+		g.drawLongRangeSensors(l.LongRange)
 	case game.Quitting:
 		g.quitting()
 	default:
@@ -264,26 +711,59 @@ func (g *Galaxy) Draw() {
 		q.DisplayStatus()
 		q.DisplayState()
 		q.DisplayMessages()
+		q.DisplayTooltip()
+		if l.Dashboard {
+			l.Screen.Emit(l.LongRange.X+2, 0, game.T("scan.title"))
+			g.drawLongRangeSensors(l.LongRange)
+			g.drawGalaxyMap(l.Galaxy)
+		}
 	}
 	game.ShowScreen()
 }
 
-// GetQuadrantSummary returns the summary for the specified quadrant
+// GetQuadrantSummary returns the summary for the specified
+// quadrant: what is there now for the active quadrant, and
+// what was last seen there for any other
 func (g *Galaxy) GetQuadrantSummary(x, y int) *game.QuadrantSummary {
-	if x >= 0 && x <= 8 && y >= 0 && y <= 8 {
-		q := g.Quadrants[x][y]
+	if !g.Rules.Galaxy.Contains(x, y) {
+		return nil
+	}
+
+	q := &g.Quadrants[x][y]
+	if x == g.ActiveQuadrantX && y == g.ActiveQuadrantY {
 		return &game.QuadrantSummary{
 			X:         x,
 			Y:         y,
 			Klingons:  q.NumberOfKlingons,
+			Romulans:  q.VisibleRomulans(),
 			Starbases: q.NumberOfStarbases,
+			Planets:   q.NumberOfPlanets,
 			Stars:     q.NumberOfStars,
-			IsActive:  x == g.ActiveQuadrantX && y == g.ActiveQuadrantY,
-			Scanned:   q.Scanned,
+			IsActive:  true,
+			Scanned:   true,
+			Supernova: q.Supernova,
+			Stardate:  g.Stardate,
+			Full:      q.EmptySectors() == 0,
 		}
 	}
 
-	return nil
+	// A supernova is seen right across the galaxy, and a full
+	// quadrant is found on the way in
+	seen := q.LastScan
+	return &game.QuadrantSummary{
+		X:         x,
+		Y:         y,
+		Klingons:  seen.Klingons,
+		Romulans:  seen.Romulans,
+		Starbases: seen.Starbases,
+		Planets:   seen.Planets,
+		Stars:     seen.Stars,
+		Scanned:   q.Scanned,
+		Supernova: q.Supernova,
+		Stardate:  seen.Stardate,
+		Stale:     q.Scanned && g.Stardate-seen.Stardate > float64(g.Rules.StaleScan),
+		Full:      q.EmptySectors() == 0,
+	}
 }
 
 // SetGameState sets the state of the game
@@ -305,12 +785,46 @@ func (g Galaxy) GetRemainingKlingons() int {
 
 // KlingonDestroyed decrements the klingon counter
 func (g *Galaxy) KlingonDestroyed() {
+	g.adjustMorale(2)
 	g.NumberOfKlingons--
 	if g.NumberOfKlingons < 0 {
 		g.NumberOfKlingons = 0
 	}
 }
 
+// GetStartingCommanders returns the number of Commanders
+// the game started with
+func (g *Galaxy) GetStartingCommanders() int {
+	return g.StartingNumberOfCommanders
+}
+
+// GetRemainingCommanders returns the remaining Commanders
+func (g *Galaxy) GetRemainingCommanders() int {
+	return g.NumberOfCommanders
+}
+
+// GetStartingSuperCommanders returns the number of
+// Super-Commanders the game started with
+func (g *Galaxy) GetStartingSuperCommanders() int {
+	return g.StartingNumberOfSuperCommanders
+}
+
+// GetRemainingSuperCommanders returns the remaining Super-Commanders
+func (g *Galaxy) GetRemainingSuperCommanders() int {
+	return g.NumberOfSuperCommanders
+}
+
+// CommanderDestroyed decrements the Commander or
+// Super-Commander counter
+func (g *Galaxy) CommanderDestroyed(super bool) {
+	g.adjustMorale(5)
+	if super {
+		g.NumberOfSuperCommanders--
+	} else {
+		g.NumberOfCommanders--
+	}
+}
+
 // GetStartingStarbases returns the number of klingons
 // game started with
 func (g *Galaxy) GetStartingStarbases() int {
@@ -324,13 +838,46 @@ func (g *Galaxy) GetRemainingStarbases() int {
 
 // StarbaseDestroyed decrements the starbase counter
 func (g *Galaxy) StarbaseDestroyed() {
+	g.adjustMorale(-10)
 	g.NumberOfStarbases--
 	if g.NumberOfStarbases < 0 {
 		g.NumberOfStarbases = 0
 	}
 }
 
+// GetCasualties returns the crew lost so far
+func (g *Galaxy) GetCasualties() int {
+	return g.Player.Casualties
+}
+
+// adjustMorale passes news of the war on to the crew
+func (g *Galaxy) adjustMorale(n int) {
+	if g.Player != nil {
+		g.Player.AdjustMorale(n)
+	}
+}
+
 // GetStardate gets the stardate
 func (g *Galaxy) GetStardate() float64 {
 	return g.Stardate
 }
+
+// GetStartingStardate gets the stardate the game began on
+func (g *Galaxy) GetStartingStardate() float64 {
+	return g.StartingStardate
+}
+
+// GetRules returns the rules this galaxy is played by
+func (g *Galaxy) GetRules() *game.Rules {
+	return g.Rules
+}
+
+// GetLog returns the galaxy's message log
+func (g *Galaxy) GetLog() *game.Log {
+	return g.Log
+}
+
+// GetRandom returns the galaxy's random source
+func (g *Galaxy) GetRandom() *game.Random {
+	return g.Random
+}
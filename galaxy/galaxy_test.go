@@ -0,0 +1,49 @@
+package galaxy
+
+import (
+	"testing"
+
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/quadrant"
+)
+
+func TestPackedGalaxy(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.Resize(game.Dimensions{Width: 4, Height: 4}, game.Dimensions{Width: 5, Height: 5})
+	g := NewGalaxyWithRules(400, 5, rules, game.NewRandom(1))
+	g.Headless = true
+	g.PlacePlayer()
+
+	if g.emptySectors() != 0 {
+		t.Errorf("got %d empty sectors, want the galaxy full", g.emptySectors())
+	}
+	if g.NumberOfKlingons >= 400 {
+		t.Errorf("got %d Klingons in %d sectors", g.NumberOfKlingons, rules.Galaxy.Area()*rules.Quadrant.Area())
+	}
+	if q := g.GetActiveQuadrant(); q.Player == nil || q.Objects[q.Player.X][q.Player.Y] != q.Player {
+		t.Error("the Enterprise was not placed")
+	}
+}
+
+func TestNoRomulansBesideStart(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.Resize(game.Dimensions{Width: 1, Height: 1}, game.Dimensions{Width: 8, Height: 8})
+	rules.Romulans = 20
+	for seed := int64(1); seed <= 50; seed++ {
+		g := NewGalaxyWithRules(0, 0, rules, game.NewRandom(seed))
+		g.Headless = true
+		g.PlacePlayer()
+
+		q := g.GetActiveQuadrant()
+		for x := q.Player.X - 1; x <= q.Player.X+1; x++ {
+			for y := q.Player.Y - 1; y <= q.Player.Y+1; y++ {
+				if !q.Size.Contains(x, y) {
+					continue
+				}
+				if _, ok := q.Objects[x][y].(*quadrant.Romulan); ok {
+					t.Errorf("seed %d: Romulan at %d,%d beside the Enterprise at %d,%d", seed, x, y, q.Player.X, q.Player.Y)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package galaxy
+
+import (
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/quadrant"
+)
+
+// Click acts on a mouse click at a screen position.  A click
+// on a sector moves the Enterprise towards it, or fires a
+// torpedo at it when the weapons are selected, and a click on
+// a quadrant of the galaxy map or long-range scan warps there.
+func (g *Galaxy) Click(x, y int) {
+	l := game.CurrentLayout()
+	switch g.GameState {
+	case game.GalaxyMap:
+		g.clickQuadrant(g.galaxyMapQuadrantAt(l.Galaxy, x, y))
+	case game.LongRangeSensors:
+		g.clickQuadrant(g.longRangeQuadrantAt(l.LongRange, x, y))
+	case game.Quadrant:
+		if sx, sy, ok := g.GetActiveQuadrant().SectorAt(x, y); ok {
+			if direction := g.GetActiveQuadrant().ClickSector(sx, sy); direction != quadrant.Dir5 {
+				g.MovePlayer(direction)
+			}
+			g.Draw()
+		} else if l.Dashboard {
+			if qx, qy, ok := g.galaxyMapQuadrantAt(l.Galaxy, x, y); ok {
+				g.clickQuadrant(qx, qy, ok)
+			} else {
+				g.clickQuadrant(g.longRangeQuadrantAt(l.LongRange, x, y))
+			}
+		}
+	}
+}
+
+// Hover shows what is in the sector under the mouse
+func (g *Galaxy) Hover(x, y int) {
+	if g.GameState != game.Quadrant {
+		return
+	}
+	q := g.GetActiveQuadrant()
+	sx, sy, ok := q.SectorAt(x, y)
+	if q.Hover(sx, sy, ok) {
+		g.Draw()
+	}
+}
+
+// clickQuadrant warps to a quadrant that was clicked on
+func (g *Galaxy) clickQuadrant(qx, qy int, ok bool) {
+	if !ok || (qx == g.ActiveQuadrantX && qy == g.ActiveQuadrantY) {
+		return
+	}
+	q := g.GetActiveQuadrant()
+	g.GameState = game.Quadrant
+	q.UpdateState(quadrant.Normal)
+	q.Warp(qx, qy)
+	g.Draw()
+}
+
+// galaxyMapQuadrantAt returns the quadrant drawn at a screen
+// position on a galaxy map drawn in the region
+func (g *Galaxy) galaxyMapQuadrantAt(screen game.Region, x, y int) (int, int, bool) {
+	r := g.galaxyMapGrid(screen)
+	cx, cy := x-r.X-1, y-r.Y-2
+	if cx < 0 || cy < 0 || cx >= g.Rules.Galaxy.Width*7 || cy >= g.Rules.Galaxy.Height {
+		return 0, 0, false
+	}
+	return cx / 7, cy, true
+}
+
+// longRangeQuadrantAt returns the quadrant drawn at a screen
+// position on a long-range scan drawn in the region
+func (g *Galaxy) longRangeQuadrantAt(r game.Region, x, y int) (int, int, bool) {
+	cx, cy := x-r.X-1, y-r.Y-1
+	if cx < 0 || cy < 0 || cx >= 3*6 || cy >= 3*4 || cx%6 == 5 || cy%4 == 3 {
+		return 0, 0, false
+	}
+	qx, qy := g.ActiveQuadrantX+cx/6-1, g.ActiveQuadrantY+cy/4-1
+	return qx, qy, g.getQuadrant(qx, qy) != nil
+}
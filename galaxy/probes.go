@@ -0,0 +1,93 @@
+package galaxy
+
+import (
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/quadrant"
+)
+
+// Probe is a deep space probe in flight across the galaxy
+type Probe struct {
+	X         int
+	Y         int
+	Direction int
+
+	// Target is how many more quadrants an armed probe
+	// flies before it detonates, or 0 if it is unarmed
+	Target int
+
+	turns int
+}
+
+// LaunchProbe sends a probe out from a quadrant on a course
+func (g *Galaxy) LaunchProbe(qx, qy, direction, target int) {
+	g.Probes = append(g.Probes, &Probe{X: qx, Y: qy, Direction: direction, Target: target})
+}
+
+// moveProbes moves each probe on to the next quadrant of its
+// course once it has had time to cross the last
+func (g *Galaxy) moveProbes() {
+	var flying []*Probe
+	for _, p := range g.Probes {
+		p.turns++
+		if p.turns < g.Rules.ProbeTurns || g.moveProbe(p) {
+			flying = append(flying, p)
+		}
+	}
+	g.Probes = flying
+}
+
+// moveProbe takes a probe into the next quadrant, scanning it
+// on the way, and returns false if the probe is gone
+func (g *Galaxy) moveProbe(p *Probe) bool {
+	p.turns = 0
+	dx, dy := quadrant.NewLocation(0, 0, p.Direction)
+	p.X, p.Y = p.X+dx, p.Y+dy
+
+	report := g.GetActiveQuadrant()
+	q := g.getQuadrant(p.X, p.Y)
+	if q == nil {
+		report.AddMessage(game.T("msg.probe_left"))
+		return false
+	}
+	q.Scan(g.Stardate)
+
+	if p.Target > 0 {
+		p.Target--
+		if p.Target == 0 {
+			g.detonateProbe(p, q)
+			return false
+		}
+	}
+
+	// Each Klingon there has a chance of picking it off
+	for i := 0; i < q.NumberOfKlingons; i++ {
+		if g.Random.CheckPercent(g.Rules.ProbeLossPercent) {
+			report.AddAlert(game.T("alert.probe_destroyed", p.X+1, p.Y+1))
+			return false
+		}
+	}
+	return true
+}
+
+// detonateProbe sets off an armed probe's warhead, which
+// turns a star in the quadrant into a supernova
+func (g *Galaxy) detonateProbe(p *Probe, q *quadrant.Quadrant) {
+	report := g.GetActiveQuadrant()
+	if q.Supernova || q.NumberOfStars == 0 {
+		report.AddMessage(game.T("msg.probe_dud", p.X+1, p.Y+1))
+		return
+	}
+	q.GoSupernova()
+	q.Scan(g.Stardate)
+	report.AddAlert(game.T("alert.probe_supernova", p.X+1, p.Y+1))
+}
+
+// probeAt checks if there is a probe in the quadrant
+func (g *Galaxy) probeAt(x, y int) bool {
+	for _, p := range g.Probes {
+		if p.X == x && p.Y == y {
+			return true
+		}
+	}
+	return false
+}
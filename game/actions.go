@@ -0,0 +1,14 @@
+package game
+
+// Orders the player can give, which take time to carry out
+// in turn-based play
+const (
+	ActionMove = iota
+	ActionWarp
+	ActionTorpedo
+	ActionPhasers
+	ActionShields
+	ActionPower
+	ActionPlanet
+	ActionProbe
+)
@@ -0,0 +1,169 @@
+package game
+
+import "time"
+
+// Game time is counted in ticks: torpedoes move a sector every
+// tick, the galaxy takes a turn every TicksPerTurn ticks, and
+// TurnsPerStardate turns make a stardate
+const (
+	TicksPerTurn     = 2
+	TurnsPerStardate = 10
+)
+
+// TickInterval is how long a tick lasts in real time at the
+// normal speed
+const TickInterval = 500 * time.Millisecond
+
+// Ways the clock can run
+const (
+	// RealTime ticks follow the wall clock at the chosen speed
+	RealTime = iota
+	// Stepped ticks only come when the player asks for one
+	Stepped
+)
+
+// Speeds are the rates the clock can run at in real time,
+// relative to the normal speed
+var Speeds = []float64{0.5, 1, 2, 4}
+
+// Clock counts the ticks of game time and decides when the
+// next one is due
+type Clock struct {
+	Mode int
+
+	ticks   int
+	speed   int
+	pending int
+	last    time.Time
+	now     func() time.Time
+}
+
+// NewClock returns a clock running in real time at the
+// normal speed
+func NewClock() *Clock {
+	return NewClockWith(time.Now)
+}
+
+// NewClockWith returns a clock that reads the time from now,
+// so it can be driven by a FakeTime
+func NewClockWith(now func() time.Time) *Clock {
+	return &Clock{Mode: RealTime, speed: 1, now: now, last: now()}
+}
+
+// Tick counts a tick, returning true if it ends a turn
+func (c *Clock) Tick() bool {
+	c.ticks++
+	return c.ticks%TicksPerTurn == 0
+}
+
+// EndTurn moves the clock on by a whole turn, for orders
+// that take a turn of their own
+func (c *Clock) EndTurn() {
+	c.ticks += TicksPerTurn
+}
+
+// Ticks returns the number of ticks counted
+func (c Clock) Ticks() int {
+	return c.ticks
+}
+
+// Turns returns the number of whole turns counted
+func (c Clock) Turns() int {
+	return c.ticks / TicksPerTurn
+}
+
+// Stardates returns the game time counted, in stardates
+func (c Clock) Stardates() float64 {
+	return float64(c.Turns()) / TurnsPerStardate
+}
+
+// Speed returns how fast the clock runs in real time
+func (c Clock) Speed() float64 {
+	return Speeds[c.speed]
+}
+
+// Faster speeds the clock up, going back to real time if it
+// was being stepped, and returns false if it is at full speed
+func (c *Clock) Faster() bool {
+	if c.Mode != RealTime {
+		c.run()
+		return true
+	}
+	if c.speed == len(Speeds)-1 {
+		return false
+	}
+	c.speed++
+	return true
+}
+
+// Slower slows the clock down, going back to real time if it
+// was being stepped, and returns false if it is at its slowest
+func (c *Clock) Slower() bool {
+	if c.Mode != RealTime {
+		c.run()
+		return true
+	}
+	if c.speed == 0 {
+		return false
+	}
+	c.speed--
+	return true
+}
+
+// Step asks for a single tick, stopping the clock first if it
+// is running in real time
+func (c *Clock) Step() {
+	c.Mode = Stepped
+	c.pending++
+}
+
+func (c *Clock) run() {
+	c.Mode = RealTime
+	c.pending = 0
+	c.Wait()
+}
+
+// Wait starts the wait for the next real time tick over again
+func (c *Clock) Wait() {
+	c.last = c.now()
+}
+
+// Due reports whether the next tick has come, and if it has,
+// starts waiting for the one after
+func (c *Clock) Due() bool {
+	if c.Mode == Stepped {
+		if c.pending == 0 {
+			return false
+		}
+		c.pending--
+		return true
+	}
+
+	now := c.now()
+	if now.Sub(c.last) < c.Interval() {
+		return false
+	}
+	c.last = now
+	return true
+}
+
+// Interval returns how long a tick lasts at the clock's speed
+func (c Clock) Interval() time.Duration {
+	return time.Duration(float64(TickInterval) / c.Speed())
+}
+
+// FakeTime is a time source that only moves when it is told
+// to, so headless games can run a clock without sleeping
+type FakeTime struct {
+	t time.Time
+}
+
+// Now returns the fake time
+func (f *FakeTime) Now() time.Time {
+	return f.t
+}
+
+// Advance moves the fake time on
+func (f *FakeTime) Advance(d time.Duration) {
+	f.t = f.t.Add(d)
+}
@@ -0,0 +1,184 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func newFakeClock() (*Clock, *FakeTime) {
+	f := &FakeTime{}
+	return NewClockWith(f.Now), f
+}
+
+func TestClockTurnsAndStardates(t *testing.T) {
+	tests := []struct {
+		ticks     int
+		turns     int
+		stardates float64
+	}{
+		{0, 0, 0},
+		{1, 0, 0},
+		{2, 1, 0.1},
+		{19, 9, 0.9},
+		{20, 10, 1},
+		{205, 102, 10.2},
+	}
+	for _, tt := range tests {
+		c, _ := newFakeClock()
+		for i := 0; i < tt.ticks; i++ {
+			c.Tick()
+		}
+		if c.Turns() != tt.turns {
+			t.Errorf("%d ticks: got %d turns, want %d", tt.ticks, c.Turns(), tt.turns)
+		}
+		if c.Stardates() != tt.stardates {
+			t.Errorf("%d ticks: got %g stardates, want %g", tt.ticks, c.Stardates(), tt.stardates)
+		}
+	}
+}
+
+func TestClockTickEndsTurns(t *testing.T) {
+	c, _ := newFakeClock()
+	for i := 1; i <= 6; i++ {
+		if got, want := c.Tick(), i%TicksPerTurn == 0; got != want {
+			t.Errorf("tick %d: ended turn %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestClockEndTurn(t *testing.T) {
+	tests := []struct {
+		ticks int
+		turns int
+	}{
+		{0, 1},
+		{1, 1},
+		{2, 2},
+		{3, 2},
+	}
+	for _, tt := range tests {
+		c, _ := newFakeClock()
+		for i := 0; i < tt.ticks; i++ {
+			c.Tick()
+		}
+		c.EndTurn()
+		if c.Turns() != tt.turns {
+			t.Errorf("%d ticks and a turn: got %d turns, want %d", tt.ticks, c.Turns(), tt.turns)
+		}
+	}
+}
+
+func TestClockSpeedLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*Clock) bool
+		presses int
+		changed []bool
+		speed   float64
+	}{
+		{"faster", (*Clock).Faster, 3, []bool{true, true, false}, 4},
+		{"slower", (*Clock).Slower, 2, []bool{true, false}, 0.5},
+	}
+	for _, tt := range tests {
+		c, _ := newFakeClock()
+		if c.Speed() != 1 {
+			t.Fatalf("%s: starts at %gx, want 1x", tt.name, c.Speed())
+		}
+		for i := 0; i < tt.presses; i++ {
+			if got := tt.change(c); got != tt.changed[i] {
+				t.Errorf("%s: press %d changed speed %v, want %v", tt.name, i+1, got, tt.changed[i])
+			}
+		}
+		if c.Speed() != tt.speed {
+			t.Errorf("%s: got %gx, want %gx", tt.name, c.Speed(), tt.speed)
+		}
+	}
+}
+
+func TestClockRealTime(t *testing.T) {
+	tests := []struct {
+		speed float64
+		wait  time.Duration
+		ticks int
+	}{
+		{0.5, 10 * time.Second, 10},
+		{1, 10 * time.Second, 20},
+		{2, 10 * time.Second, 40},
+		{4, 10 * time.Second, 80},
+	}
+	for _, tt := range tests {
+		c, f := newFakeClock()
+		for c.Speed() < tt.speed {
+			c.Faster()
+		}
+		for c.Speed() > tt.speed {
+			c.Slower()
+		}
+
+		ticks := 0
+		for waited := time.Duration(0); waited < tt.wait; waited += c.Interval() {
+			f.Advance(c.Interval())
+			for c.Due() {
+				ticks++
+			}
+		}
+		if ticks != tt.ticks {
+			t.Errorf("%gx: got %d ticks in %v, want %d", tt.speed, ticks, tt.wait, tt.ticks)
+		}
+	}
+}
+
+func TestClockNotDueEarly(t *testing.T) {
+	c, f := newFakeClock()
+	f.Advance(TickInterval - time.Millisecond)
+	if c.Due() {
+		t.Error("tick due before the interval was up")
+	}
+	f.Advance(time.Millisecond)
+	if !c.Due() {
+		t.Error("tick not due once the interval was up")
+	}
+	if c.Due() {
+		t.Error("second tick due without waiting")
+	}
+}
+
+func TestClockStepMode(t *testing.T) {
+	c, f := newFakeClock()
+	c.Step()
+	c.Step()
+	if c.Mode != Stepped {
+		t.Fatalf("got mode %d, want Stepped", c.Mode)
+	}
+
+	f.Advance(time.Hour)
+	due := 0
+	for i := 0; i < 5; i++ {
+		if c.Due() {
+			due++
+		}
+	}
+	if due != 2 {
+		t.Errorf("got %d ticks for two steps, want 2", due)
+	}
+
+	if !c.Faster() || c.Mode != RealTime {
+		t.Error("speeding up didn't set the clock running again")
+	}
+	if c.Speed() != 1 {
+		t.Errorf("leaving step mode changed the speed to %gx", c.Speed())
+	}
+	if c.Due() {
+		t.Error("tick due at once on leaving step mode")
+	}
+}
+
+func TestClockWait(t *testing.T) {
+	c, f := newFakeClock()
+	f.Advance(TickInterval - time.Millisecond)
+	c.Wait()
+	f.Advance(time.Millisecond)
+	if c.Due() {
+		t.Error("tick due before a full interval after waiting")
+	}
+}
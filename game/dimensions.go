@@ -0,0 +1,56 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Limits on the size of the galaxy, in quadrants, and of
+// each quadrant, in sectors
+const (
+	MinGalaxySize   = 1
+	MaxGalaxySize   = 16
+	MinQuadrantSize = 5
+	MaxQuadrantSize = 16
+)
+
+// Dimensions are the width and height of the galaxy in quadrants,
+// or of a quadrant in sectors
+type Dimensions struct {
+	Width  int
+	Height int
+}
+
+// ParseDimensions reads dimensions written as WxH, such as 8x8
+func ParseDimensions(s string) (Dimensions, error) {
+	parts := strings.Split(strings.ToLower(s), "x")
+	if len(parts) != 2 {
+		return Dimensions{}, fmt.Errorf("size %q should be written as WxH, such as 8x8", s)
+	}
+	w, err1 := strconv.Atoi(parts[0])
+	h, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return Dimensions{}, fmt.Errorf("size %q should be written as WxH, such as 8x8", s)
+	}
+	return Dimensions{Width: w, Height: h}, nil
+}
+
+func (s Dimensions) String() string {
+	return fmt.Sprintf("%dx%d", s.Width, s.Height)
+}
+
+// Contains checks if the 0-based x, y lies inside
+func (s Dimensions) Contains(x, y int) bool {
+	return x >= 0 && x < s.Width && y >= 0 && y < s.Height
+}
+
+// Area returns the number of quadrants or sectors
+func (s Dimensions) Area() int {
+	return s.Width * s.Height
+}
+
+// Within checks that both sides lie between min and max
+func (s Dimensions) Within(min, max int) bool {
+	return s.Width >= min && s.Width <= max && s.Height >= min && s.Height <= max
+}
@@ -0,0 +1,92 @@
+package game
+
+import "testing"
+
+func TestParseDimensions(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Dimensions
+		ok   bool
+	}{
+		{"8x8", Dimensions{8, 8}, true},
+		{"16x12", Dimensions{16, 12}, true},
+		{"4X6", Dimensions{4, 6}, true},
+		{"0x0", Dimensions{0, 0}, true},
+		{"8", Dimensions{}, false},
+		{"8x", Dimensions{}, false},
+		{"x8", Dimensions{}, false},
+		{"8x8x8", Dimensions{}, false},
+		{"eightxeight", Dimensions{}, false},
+		{"", Dimensions{}, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseDimensions(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("%q: got error %v, want ok %v", tt.in, err, tt.ok)
+		} else if got != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDimensionsWithin(t *testing.T) {
+	tests := []struct {
+		d    Dimensions
+		want bool
+	}{
+		{Dimensions{1, 1}, false},
+		{Dimensions{5, 5}, true},
+		{Dimensions{16, 5}, true},
+		{Dimensions{17, 8}, false},
+		{Dimensions{8, 4}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.d.Within(MinQuadrantSize, MaxQuadrantSize); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestRulesScale(t *testing.T) {
+	tests := []struct {
+		galaxy Dimensions
+		n      int
+		want   int
+	}{
+		{Dimensions{8, 8}, 25, 25},
+		{Dimensions{8, 8}, 0, 0},
+		{Dimensions{4, 4}, 25, 6},
+		{Dimensions{4, 4}, 2, 1},
+		{Dimensions{1, 1}, 1, 1},
+		{Dimensions{1, 1}, 0, 0},
+		{Dimensions{16, 16}, 25, 100},
+		{Dimensions{16, 8}, 5, 10},
+	}
+	for _, tt := range tests {
+		r := DefaultRules()
+		r.Galaxy = tt.galaxy
+		if got := r.Scale(tt.n); got != tt.want {
+			t.Errorf("%d in %v: got %d, want %d", tt.n, tt.galaxy, got, tt.want)
+		}
+	}
+}
+
+func TestRulesResize(t *testing.T) {
+	r := DefaultRules()
+	r.Resize(Dimensions{4, 4}, Dimensions{12, 12})
+	if r.Galaxy != (Dimensions{4, 4}) || r.Quadrant != (Dimensions{12, 12}) {
+		t.Errorf("got galaxy %v and quadrants %v", r.Galaxy, r.Quadrant)
+	}
+	if r.Romulans != 2 || r.Commanders != 1 || r.SuperCommanders != 1 || r.Planets != 3 {
+		t.Errorf("got %d Romulans, %d Commanders, %d Super-Commanders and %d planets, want 2, 1, 1 and 3",
+			r.Romulans, r.Commanders, r.SuperCommanders, r.Planets)
+	}
+	if err := r.CheckSizes(); err != nil {
+		t.Error(err)
+	}
+
+	r.Resize(Dimensions{20, 8}, Dimensions{10, 10})
+	if r.CheckSizes() == nil {
+		t.Error("a 20x8 galaxy passed the size check")
+	}
+}
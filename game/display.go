@@ -8,7 +8,8 @@ import (
 	"github.com/mattn/go-runewidth"
 )
 
-// Minimum width/height of console
+// Minimum width/height of console; anything smaller
+// is covered by a request to enlarge the terminal
 const (
 	MinWidth  = 80
 	MinHeight = 25
@@ -29,17 +30,9 @@ func InitScreen() error {
 	if e := scr.Init(); e != nil {
 		return e
 	}
+	scr.EnableMouse()
 
-	w, h := scr.Size()
-	if w < MinWidth || h < MinHeight {
-		CloseScreen()
-		return fmt.Errorf("console too small: found %d by %d, expected at least %d by %d", w, h, MinWidth, MinHeight)
-	}
-
-	defStyle := tcell.StyleDefault.
-		Background(tcell.ColorBlack).
-		Foreground(tcell.ColorWhite)
-	s.SetStyle(defStyle)
+	s.SetStyle(Style("text"))
 
 	return nil
 }
@@ -79,6 +72,11 @@ func PollForEvents() chan tcell.Event {
 
 // EmitStr will print a string to the screen
 func EmitStr(x, y int, str string) {
+	EmitStrStyle(x, y, str, Style("text"))
+}
+
+// EmitStrStyle will print a string to the screen in the given style
+func EmitStrStyle(x, y int, str string, style tcell.Style) {
 	for _, c := range str {
 		var comb []rune
 		w := runewidth.RuneWidth(c)
@@ -87,7 +85,7 @@ func EmitStr(x, y int, str string) {
 			c = ' '
 			w = 1
 		}
-		scr.SetContent(x, y, c, comb, tcell.StyleDefault)
+		scr.SetContent(x, y, c, comb, style)
 		x += w
 	}
 }
@@ -104,25 +102,26 @@ func DrawBox(x1, y1, x2, y2 int) {
 	// Fill background
 	for row := y1; row <= y2; row++ {
 		for col := x1; col <= x2; col++ {
-			scr.SetContent(col, row, ' ', nil, tcell.StyleDefault)
+			scr.SetContent(col, row, ' ', nil, Style("text"))
 		}
 	}
 
 	// Draw borders
+	border := Style("border")
 	for col := x1; col <= x2; col++ {
-		scr.SetContent(col, y1, tcell.RuneHLine, nil, tcell.StyleDefault)
-		scr.SetContent(col, y2, tcell.RuneHLine, nil, tcell.StyleDefault)
+		scr.SetContent(col, y1, tcell.RuneHLine, nil, border)
+		scr.SetContent(col, y2, tcell.RuneHLine, nil, border)
 	}
 	for row := y1 + 1; row < y2; row++ {
-		scr.SetContent(x1, row, tcell.RuneVLine, nil, tcell.StyleDefault)
-		scr.SetContent(x2, row, tcell.RuneVLine, nil, tcell.StyleDefault)
+		scr.SetContent(x1, row, tcell.RuneVLine, nil, border)
+		scr.SetContent(x2, row, tcell.RuneVLine, nil, border)
 	}
 
 	// Only draw corners if necessary
 	if y1 != y2 && x1 != x2 {
-		scr.SetContent(x1, y1, tcell.RuneULCorner, nil, tcell.StyleDefault)
-		scr.SetContent(x2, y1, tcell.RuneURCorner, nil, tcell.StyleDefault)
-		scr.SetContent(x1, y2, tcell.RuneLLCorner, nil, tcell.StyleDefault)
-		scr.SetContent(x2, y2, tcell.RuneLRCorner, nil, tcell.StyleDefault)
+		scr.SetContent(x1, y1, tcell.RuneULCorner, nil, border)
+		scr.SetContent(x2, y1, tcell.RuneURCorner, nil, border)
+		scr.SetContent(x1, y2, tcell.RuneLLCorner, nil, border)
+		scr.SetContent(x2, y2, tcell.RuneLRCorner, nil, border)
 	}
 }
@@ -4,6 +4,8 @@ package game
 const (
 	EnterpriseMaxEnergy    = 5000
 	EnterpriseMaxTorpedoes = 20
+	EnterpriseMaxCrew      = 430
+	EnterpriseMaxProbes    = 3
 )
 
 // Constants for game UI state
@@ -14,16 +16,51 @@ const (
 	Quitting
 )
 
+// Outcomes of the game
+const (
+	Playing = iota
+	Won
+	PlayerDestroyed
+	OutOfTime
+	StarbasesLost
+)
+
+// Conditions decide when the game is won or lost.  The zero
+// value is the standard war: destroy every Klingon, with no
+// time limit
+type Conditions struct {
+	// KlingonsRemaining is how many Klingons may survive for the player to win
+	KlingonsRemaining int
+
+	// TimeLimit is the number of stardates allowed, or 0 for no limit
+	TimeLimit float64
+
+	// MinimumStarbases is how many starbases must survive
+	MinimumStarbases int
+}
+
 // QuadrantSummary gives summary of quadrant
 // that is used to display galaxy map
 type QuadrantSummary struct {
 	X         int
 	Y         int
 	Klingons  int
+	Romulans  int
 	Starbases int
+	Planets   int
 	Stars     int
 	IsActive  bool
 	Scanned   bool
+	Supernova bool
+
+	// Stardate is when the counts were seen, and Stale
+	// is true if that was too long ago to rely on
+	Stardate float64
+	Stale    bool
+
+	// Full is true if there is no room left in the quadrant
+	// for the Enterprise to arrive
+	Full bool
 }
 
 // Game is the global object with all the overall game state
@@ -31,17 +68,31 @@ type Game interface {
 	GetStartingKlingons() int
 	GetRemainingKlingons() int
 	KlingonDestroyed()
+	GetStartingCommanders() int
+	GetRemainingCommanders() int
+	GetStartingSuperCommanders() int
+	GetRemainingSuperCommanders() int
+	CommanderDestroyed(super bool)
 	GetStartingStarbases() int
 	GetRemainingStarbases() int
 	StarbaseDestroyed()
 
+	GetCasualties() int
 	GetStardate() float64
+	GetStartingStardate() float64
+
+	GetRules() *Rules
+	GetLog() *Log
+	GetRandom() *Random
 
 	GetQuadrantSummary(x, y int) *QuadrantSummary
 
 	SetGameState(state int)
 
 	NavigateTo(x, y int)
+	WormholeTo(qx, qy, sx, sy int)
+	LaunchProbe(qx, qy, direction, target int)
+	Spend(action int)
 
 	Draw()
 }
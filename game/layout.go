@@ -0,0 +1,224 @@
+package game
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// Sizes of the fixed parts of the quadrant screen
+const (
+	StatusWidth  = 31
+	StatusHeight = 14
+	statusGap    = 4
+
+	LongRangeWidth  = 19
+	LongRangeHeight = 13
+
+	// The message log needs at least this many rows
+	messagesHeight = 10
+)
+
+// The galaxy and quadrants the displays are laid out for
+var (
+	galaxySize   = Dimensions{Width: 8, Height: 8}
+	quadrantSize = Dimensions{Width: 10, Height: 10}
+)
+
+// SetMapSizes lays the displays out for a galaxy and
+// quadrants of the given dimensions
+func SetMapSizes(galaxy, quadrant Dimensions) {
+	galaxySize, quadrantSize = galaxy, quadrant
+}
+
+// MapWidth is the width of the sector map, with its
+// border and numbering
+func MapWidth() int {
+	return quadrantSize.Width*4 + 5
+}
+
+// MapHeight is the height of the sector map
+func MapHeight() int {
+	return quadrantSize.Height + 3
+}
+
+// GalaxyWidth is the width of the galaxy map, which is at
+// least wide enough for its legend
+func GalaxyWidth() int {
+	return Max(galaxySize.Width*7+3, 70)
+}
+
+// GalaxyHeight is the height of the galaxy map, with its
+// title, summary and legend
+func GalaxyHeight() int {
+	return galaxySize.Height + 7
+}
+
+// topHeight is the height of the map and status panel,
+// with the tooltip line under the map
+func topHeight() int {
+	return Max(MapHeight()+1, StatusHeight)
+}
+
+// DashboardWidth is the narrowest terminal that can show
+// every display at once
+func DashboardWidth() int {
+	return Max(MapWidth()+statusGap+StatusWidth+4+LongRangeWidth, GalaxyWidth())
+}
+
+// DashboardHeight is the shortest terminal that can show
+// every display at once
+func DashboardHeight() int {
+	return topHeight() + GalaxyHeight() + 1 + messagesHeight
+}
+
+// MinimumSize returns the smallest terminal the game can be
+// played in
+func MinimumSize() (int, int) {
+	w := Max(MinWidth, MapWidth()+statusGap+StatusWidth)
+	h := Max(MinHeight, topHeight()+1+messagesHeight)
+	return Max(w, GalaxyWidth()), Max(h, GalaxyHeight())
+}
+
+// dashboard is whether wide terminals use the dashboard
+var dashboard = true
+
+// Region is a rectangle of the screen that one display
+// draws into, using coordinates relative to its corner
+type Region struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Emit prints a string in the region, clipped to its edges
+func (r Region) Emit(x, y int, str string) {
+	r.EmitStyle(x, y, str, Style("text"))
+}
+
+// EmitStyle prints a string in the region in the given style,
+// clipped to its edges
+func (r Region) EmitStyle(x, y int, str string, style tcell.Style) {
+	if x < 0 || y < 0 || x >= r.Width || y >= r.Height {
+		return
+	}
+	EmitStrStyle(r.X+x, r.Y+y, runewidth.Truncate(str, r.Width-x, ""), style)
+}
+
+// EmitCentred prints a string centred across a row of the region
+func (r Region) EmitCentred(y int, str string) {
+	x := (r.Width - runewidth.StringWidth(str)) / 2
+	if x < 0 {
+		x = 0
+	}
+	r.Emit(x, y, str)
+}
+
+// Centre returns a region of the given size in the middle
+// of this one, shrunk to fit if it is too big
+func (r Region) Centre(width, height int) Region {
+	if width > r.Width {
+		width = r.Width
+	}
+	if height > r.Height {
+		height = r.Height
+	}
+	return Region{
+		X:      r.X + (r.Width-width)/2,
+		Y:      r.Y + (r.Height-height)/2,
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Layout divides the screen into the regions of the
+// quadrant display.  Off the dashboard, the long-range scan
+// and galaxy map are centred on the screen, for when they
+// are shown in place of the quadrant.
+type Layout struct {
+	Screen    Region
+	Map       Region
+	Tooltip   Region
+	Status    Region
+	State     Region
+	Messages  Region
+	LongRange Region
+	Galaxy    Region
+	Dashboard bool
+}
+
+// NewLayout arranges the quadrant display for a screen of the
+// given size.  The map and status panel are centred across the
+// top, with the command line under them and the message log
+// taking whatever room is left.  If the dashboard is wanted and
+// fits, the long-range scan sits beside the status panel and the
+// galaxy map between them and the command line.
+func NewLayout(w, h int, wantDashboard bool) Layout {
+	screen := Region{Width: w, Height: h}
+	mapWidth, mapHeight, topHeight := MapWidth(), MapHeight(), topHeight()
+	if wantDashboard && w >= DashboardWidth() && h >= DashboardHeight() {
+		top := screen.Centre(DashboardWidth(), h)
+		state := topHeight + GalaxyHeight()
+		return Layout{
+			Screen:    screen,
+			Map:       Region{X: top.X, Y: 0, Width: mapWidth, Height: mapHeight},
+			Tooltip:   Region{X: top.X + 1, Y: mapHeight, Width: mapWidth + statusGap - 1, Height: 1},
+			Status:    Region{X: top.X + mapWidth + statusGap, Y: 0, Width: StatusWidth, Height: StatusHeight},
+			LongRange: Region{X: top.X + top.Width - LongRangeWidth, Y: 1, Width: LongRangeWidth, Height: LongRangeHeight},
+			Galaxy:    Region{X: top.X, Y: topHeight, Width: top.Width, Height: GalaxyHeight()},
+			State:     Region{X: top.X + 1, Y: state, Width: w - top.X - 1, Height: 1},
+			Messages:  Region{X: top.X + 1, Y: state + 1, Width: w - top.X - 1, Height: h - state - 1},
+			Dashboard: true,
+		}
+	}
+
+	top := screen.Centre(Max(MinWidth, mapWidth+statusGap+StatusWidth), h)
+
+	return Layout{
+		Screen:    screen,
+		Map:       Region{X: top.X, Y: 0, Width: mapWidth, Height: mapHeight},
+		Tooltip:   Region{X: top.X + 1, Y: mapHeight, Width: mapWidth + statusGap - 1, Height: 1},
+		Status:    Region{X: top.X + mapWidth + statusGap, Y: 0, Width: StatusWidth, Height: StatusHeight},
+		State:     Region{X: top.X + 1, Y: topHeight, Width: w - top.X - 1, Height: 1},
+		Messages:  Region{X: top.X + 1, Y: topHeight + 1, Width: w - top.X - 1, Height: h - topHeight - 1},
+		LongRange: screen.Centre(LongRangeWidth, LongRangeHeight),
+		Galaxy:    screen.Centre(GalaxyWidth(), GalaxyHeight()),
+	}
+}
+
+// CurrentLayout returns the layout for the screen as it is now
+func CurrentLayout() Layout {
+	w, h := Size()
+	return NewLayout(w, h, dashboard)
+}
+
+// ToggleDashboard switches wide terminals between the
+// dashboard and the single screen display
+func ToggleDashboard() {
+	dashboard = !dashboard
+}
+
+// SetDashboard sets whether wide terminals use the dashboard
+func SetDashboard(on bool) {
+	dashboard = on
+}
+
+// TooSmall reports whether the screen is smaller than the game needs
+func TooSmall() bool {
+	w, h := Size()
+	minW, minH := MinimumSize()
+	return w < minW || h < minH
+}
+
+// DrawTooSmall replaces the display with a request to
+// enlarge the terminal
+func DrawTooSmall() {
+	w, h := Size()
+	screen := Region{Width: w, Height: h}
+	minW, minH := MinimumSize()
+	lines := Lines("screen.too_small", w, h, minW, minH)
+
+	ClearScreen()
+	for i, l := range lines {
+		screen.EmitCentred(h/2-1+i, l)
+	}
+	ShowScreen()
+}
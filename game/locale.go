@@ -0,0 +1,219 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocaleDir is where catalogues are looked for by name, relative
+// to the working directory or to the program
+const LocaleDir = "locales"
+
+// Catalogue is the player-facing text in one language, keyed by
+// message ID.  A message is either a single string or, when it
+// depends on a number, an object giving the text for each plural
+// form ("one", "few", "many" and "other").
+type Catalogue struct {
+	Locale   string                     `json:"locale"`
+	Name     string                     `json:"name"`
+	Plural   string                     `json:"plural"`
+	Messages map[string]json.RawMessage `json:"messages"`
+
+	text map[string]map[string]string
+}
+
+// Plural rules, picking the form of a message for a number
+var pluralRules = map[string]func(n int) string{
+	// English, German and most of Europe: 1 is singular
+	"one-other": func(n int) string {
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	},
+	// French and Portuguese: 0 and 1 are singular
+	"french": func(n int) string {
+		if n == 0 || n == 1 {
+			return "one"
+		}
+		return "other"
+	},
+	// Polish, and others like it: 2-4 and 22-24 take a form of their own
+	"slavic": func(n int) string {
+		switch {
+		case n == 1:
+			return "one"
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return "few"
+		}
+		return "many"
+	},
+	// Languages that don't change with the number
+	"none": func(n int) string {
+		return "other"
+	},
+}
+
+var english = newEnglish()
+
+var catalogue = english
+
+func newEnglish() *Catalogue {
+	c := &Catalogue{Locale: "en", Name: "English", Plural: "one-other", text: map[string]map[string]string{}}
+	for id, t := range englishMessages {
+		c.text[id] = map[string]string{"other": t}
+	}
+	for id, forms := range englishPlurals {
+		c.text[id] = forms
+	}
+	return c
+}
+
+// LoadCatalogue returns the catalogue for a locale, which is either
+// "en", the name of a file in the locales directory such as "de",
+// or the path of a catalogue file
+func LoadCatalogue(name string) (*Catalogue, error) {
+	if name == "" || name == "en" {
+		return english, nil
+	}
+
+	data, err := ioutil.ReadFile(findCatalogue(name))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no catalogue for locale %q in %s", name, LocaleDir)
+	} else if err != nil {
+		return nil, err
+	}
+	c := &Catalogue{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if err := c.compile(); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return c, nil
+}
+
+// findCatalogue returns the file to load for a locale name
+func findCatalogue(name string) string {
+	if strings.HasSuffix(name, ".json") {
+		return name
+	}
+	file := filepath.Join(LocaleDir, name+".json")
+	if _, err := os.Stat(file); err == nil {
+		return file
+	}
+	if exe, err := os.Executable(); err == nil {
+		return filepath.Join(filepath.Dir(exe), file)
+	}
+	return file
+}
+
+// compile checks the catalogue and sorts out its messages
+func (c *Catalogue) compile() error {
+	if c.Plural == "" {
+		c.Plural = "one-other"
+	}
+	if _, ok := pluralRules[c.Plural]; !ok {
+		return fmt.Errorf("unknown plural rule %q", c.Plural)
+	}
+
+	c.text = map[string]map[string]string{}
+	for id, raw := range c.Messages {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			c.text[id] = map[string]string{"other": s}
+			continue
+		}
+		forms := map[string]string{}
+		if err := json.Unmarshal(raw, &forms); err != nil {
+			return fmt.Errorf("message %q must be a string or an object of plural forms", id)
+		}
+		c.text[id] = forms
+	}
+	return nil
+}
+
+// Missing lists the English messages the catalogue has no text for
+func (c *Catalogue) Missing() []string {
+	var result []string
+	for id := range english.text {
+		if _, ok := c.text[id]; !ok {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// lookup finds the text of a message in the form for n
+func (c *Catalogue) lookup(id string, n int) (string, bool) {
+	forms, ok := c.text[id]
+	if !ok {
+		return "", false
+	}
+	if t, ok := forms[pluralRules[c.Plural](n)]; ok {
+		return t, true
+	}
+	t, ok := forms["other"]
+	return t, ok
+}
+
+// SetCatalogue changes the language of the game's text
+func SetCatalogue(c *Catalogue) {
+	catalogue = c
+}
+
+// EnvironmentLocale returns the locale asked for by KABTREK_LANG,
+// or else the usual LC_ALL, LC_MESSAGES and LANG, as a language
+// code such as "de"
+func EnvironmentLocale() string {
+	for _, v := range []string{"KABTREK_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(v)
+		if value == "" {
+			continue
+		}
+		if i := strings.IndexAny(value, "_.@"); i >= 0 {
+			value = value[:i]
+		}
+		if value == "C" || value == "POSIX" {
+			return "en"
+		}
+		return value
+	}
+	return "en"
+}
+
+// T returns the text of a message in the current language,
+// formatted with the arguments given
+func T(id string, args ...interface{}) string {
+	return TN(id, 0, args...)
+}
+
+// TN returns the text of a message in the plural form for n,
+// formatted with the arguments given
+func TN(id string, n int, args ...interface{}) string {
+	t, ok := catalogue.lookup(id, n)
+	if !ok {
+		if t, ok = english.lookup(id, n); !ok {
+			t = id
+		}
+	}
+	if len(args) == 0 {
+		return t
+	}
+	return fmt.Sprintf(t, args...)
+}
+
+// Lines returns a message of several lines as a slice
+func Lines(id string, args ...interface{}) []string {
+	return strings.Split(T(id, args...), "\n")
+}
+
+// LinesN returns a message of several lines in the plural
+// form for n, as a slice
+func LinesN(id string, n int, args ...interface{}) []string {
+	return strings.Split(TN(id, n, args...), "\n")
+}
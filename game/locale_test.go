@@ -0,0 +1,88 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPluralRules(t *testing.T) {
+	tests := []struct {
+		rule  string
+		forms map[int]string
+	}{
+		{"one-other", map[int]string{0: "other", 1: "one", 2: "other", 11: "other", 21: "other"}},
+		{"french", map[int]string{0: "one", 1: "one", 2: "other", 100: "other"}},
+		{"slavic", map[int]string{0: "many", 1: "one", 2: "few", 4: "few", 5: "many", 12: "many", 14: "many", 22: "few", 25: "many", 111: "many", 112: "many", 122: "few"}},
+		{"none", map[int]string{0: "other", 1: "other", 2: "other"}},
+	}
+	for _, tt := range tests {
+		rule, ok := pluralRules[tt.rule]
+		if !ok {
+			t.Errorf("no plural rule %q", tt.rule)
+			continue
+		}
+		for n, want := range tt.forms {
+			if got := rule(n); got != want {
+				t.Errorf("%s, %d: got %q, want %q", tt.rule, n, got, want)
+			}
+		}
+	}
+}
+
+func TestCatalogueLookup(t *testing.T) {
+	c := &Catalogue{
+		Plural: "slavic",
+		Messages: map[string]json.RawMessage{
+			"plain":   json.RawMessage(`"text"`),
+			"counted": json.RawMessage(`{"one": "%d ship", "few": "%d ships (few)", "other": "%d ships"}`),
+		},
+	}
+	if err := c.compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id   string
+		n    int
+		want string
+		ok   bool
+	}{
+		{"plain", 1, "text", true},
+		{"plain", 5, "text", true},
+		{"counted", 1, "%d ship", true},
+		{"counted", 3, "%d ships (few)", true},
+		{"counted", 5, "%d ships", true},
+		{"missing", 1, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := c.lookup(tt.id, tt.n)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s, %d: got %q, %v, want %q, %v", tt.id, tt.n, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCatalogueCompileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Catalogue
+	}{
+		{"unknown rule", Catalogue{Plural: "klingon"}},
+		{"bad message", Catalogue{Messages: map[string]json.RawMessage{"bad": json.RawMessage(`12`)}}},
+	}
+	for _, tt := range tests {
+		if err := tt.c.compile(); err == nil {
+			t.Errorf("%s: compiled without an error", tt.name)
+		}
+	}
+}
+
+func TestGermanCatalogue(t *testing.T) {
+	c, err := LoadCatalogue("../locales/de.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing := c.Missing(); len(missing) > 0 {
+		t.Errorf("German catalogue is missing %v", missing)
+	}
+}
@@ -0,0 +1,133 @@
+package game
+
+// Severity of a message
+const (
+	Info = iota
+	Combat
+	Alert
+)
+
+// Filters for the message log
+const (
+	ShowAll = iota
+	ShowCombat
+	ShowAlerts
+)
+
+// FilterNames are the display names of the filters
+var FilterNames = []string{"ALL", "COMBAT", "ALERTS"}
+
+// MaxLogMessages is how many messages the log keeps
+const MaxLogMessages = 1000
+
+// Message is a single line in the log
+type Message struct {
+	Text     string
+	Stardate float64
+	Severity int
+}
+
+// Log keeps the messages for the whole game, with a
+// filtered view that can be scrolled back through
+type Log struct {
+	Messages []Message
+	Filter   int
+
+	// scroll is how many lines back from the newest the view is
+	scroll int
+
+	// added counts every message ever added
+	added int
+}
+
+// NewLog creates an empty log
+func NewLog() *Log {
+	return &Log{}
+}
+
+// Add puts a message at the end of the log.  A view that
+// has been scrolled back stays where it is.
+func (l *Log) Add(stardate float64, severity int, text string) {
+	m := Message{Text: text, Stardate: stardate, Severity: severity}
+	l.Messages = append(l.Messages, m)
+	l.added++
+	if len(l.Messages) > MaxLogMessages {
+		l.Messages = l.Messages[len(l.Messages)-MaxLogMessages:]
+	}
+	if l.scroll > 0 && l.shows(m) {
+		l.scroll++
+	}
+}
+
+// Since returns the messages added after the first n, as far
+// as the log still holds them, and how many have been added
+// in all, ready for the next call
+func (l *Log) Since(n int) ([]Message, int) {
+	newer := l.added - n
+	if newer > len(l.Messages) {
+		newer = len(l.Messages)
+	}
+	if newer < 0 {
+		newer = 0
+	}
+	return l.Messages[len(l.Messages)-newer:], l.added
+}
+
+func (l *Log) shows(m Message) bool {
+	switch l.Filter {
+	case ShowCombat:
+		return m.Severity == Combat
+	case ShowAlerts:
+		return m.Severity == Alert
+	}
+	return true
+}
+
+// visible returns the messages that pass the filter
+func (l *Log) visible() []Message {
+	var result []Message
+	for _, m := range l.Messages {
+		if l.shows(m) {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// Page returns up to n messages for the view, oldest first,
+// and how many newer ones are scrolled out of sight
+func (l *Log) Page(n int) ([]Message, int) {
+	v := l.visible()
+	if l.scroll > len(v)-n {
+		l.scroll = len(v) - n
+	}
+	if l.scroll < 0 {
+		l.scroll = 0
+	}
+	end := len(v) - l.scroll
+	start := end - n
+	if start < 0 {
+		start = 0
+	}
+	return v[start:end], l.scroll
+}
+
+// ScrollUp moves the view n lines back through the log
+func (l *Log) ScrollUp(n int) {
+	l.scroll += n
+}
+
+// ScrollDown moves the view n lines toward the newest
+func (l *Log) ScrollDown(n int) {
+	l.scroll -= n
+	if l.scroll < 0 {
+		l.scroll = 0
+	}
+}
+
+// CycleFilter moves on to the next filter, showing the
+// newest messages
+func (l *Log) CycleFilter() {
+	l.Filter = (l.Filter + 1) % len(FilterNames)
+	l.scroll = 0
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+)
+
+// fillLog adds n messages to the log, every third of them an
+// alert and the rest combat reports
+func fillLog(l *Log, n int) {
+	for i := 0; i < n; i++ {
+		severity := Combat
+		if i%3 == 2 {
+			severity = Alert
+		}
+		l.Add(0, severity, fmt.Sprint(i))
+	}
+}
+
+func texts(messages []Message) []string {
+	result := []string{}
+	for _, m := range messages {
+		result = append(result, m.Text)
+	}
+	return result
+}
+
+func TestLogSince(t *testing.T) {
+	tests := []struct {
+		added int
+		since int
+		want  int
+		first string
+	}{
+		{0, 0, 0, ""},
+		{5, 0, 5, "0"},
+		{5, 3, 2, "3"},
+		{5, 5, 0, ""},
+		{5, 9, 0, ""},
+		{MaxLogMessages + 10, 0, MaxLogMessages, "10"},
+		{MaxLogMessages + 10, MaxLogMessages, 10, fmt.Sprint(MaxLogMessages)},
+	}
+	for _, tt := range tests {
+		l := NewLog()
+		fillLog(l, tt.added)
+		got, total := l.Since(tt.since)
+		if len(got) != tt.want {
+			t.Errorf("%d added, since %d: got %d messages, want %d", tt.added, tt.since, len(got), tt.want)
+		} else if len(got) > 0 && got[0].Text != tt.first {
+			t.Errorf("%d added, since %d: first is %q, want %q", tt.added, tt.since, got[0].Text, tt.first)
+		}
+		if total != tt.added {
+			t.Errorf("%d added: got a total of %d", tt.added, total)
+		}
+	}
+}
+
+func TestLogPage(t *testing.T) {
+	tests := []struct {
+		name   string
+		added  int
+		filter int
+		scroll int
+		page   int
+		want   []string
+		hidden int
+	}{
+		{"newest", 10, ShowAll, 0, 3, []string{"7", "8", "9"}, 0},
+		{"short log", 2, ShowAll, 0, 3, []string{"0", "1"}, 0},
+		{"scrolled back", 10, ShowAll, 4, 3, []string{"3", "4", "5"}, 4},
+		{"scrolled past the start", 10, ShowAll, 50, 3, []string{"0", "1", "2"}, 7},
+		{"alerts", 10, ShowAlerts, 0, 2, []string{"5", "8"}, 0},
+		{"alerts scrolled back", 10, ShowAlerts, 1, 2, []string{"2", "5"}, 1},
+		{"combat", 6, ShowCombat, 0, 10, []string{"0", "1", "3", "4"}, 0},
+	}
+	for _, tt := range tests {
+		l := NewLog()
+		fillLog(l, tt.added)
+		l.Filter = tt.filter
+		l.ScrollUp(tt.scroll)
+		got, hidden := l.Page(tt.page)
+		if fmt.Sprint(texts(got)) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, texts(got), tt.want)
+		}
+		if hidden != tt.hidden {
+			t.Errorf("%s: got %d newer hidden, want %d", tt.name, hidden, tt.hidden)
+		}
+	}
+}
+
+func TestLogScrolledViewStays(t *testing.T) {
+	l := NewLog()
+	fillLog(l, 10)
+	l.ScrollUp(2)
+	l.Add(0, Info, "new")
+	got, hidden := l.Page(3)
+	if fmt.Sprint(texts(got)) != "[5 6 7]" || hidden != 3 {
+		t.Errorf("got %v with %d hidden, want [5 6 7] with 3", texts(got), hidden)
+	}
+
+	l.ScrollDown(10)
+	got, hidden = l.Page(3)
+	if fmt.Sprint(texts(got)) != "[8 9 new]" || hidden != 0 {
+		t.Errorf("got %v with %d hidden, want [8 9 new] with 0", texts(got), hidden)
+	}
+}
@@ -0,0 +1,256 @@
+package game
+
+// englishMessages is the built-in English text, which every
+// other catalogue is translated from and falls back to
+var englishMessages = map[string]string{
+	"alert.black_hole":            "** %s swallowed by the black hole at %d, %d! **",
+	"alert.dilithium_explosion":   "** Dilithium chamber explosion! %d energy lost **",
+	"alert.entered":               "** %s has entered the quadrant at %d, %d! **",
+	"alert.life_support":          "** Energy exhausted!  Life support is on reserves **",
+	"alert.no_engine_energy":      "** No energy for the engines! **",
+	"alert.no_engine_power":       "** No power to the engines! **",
+	"alert.no_probes":             "** No probes left **",
+	"alert.no_weapon_power":       "** No power to the weapons! **",
+	"alert.nova":                  "** Star at %d, %d goes nova! **",
+	"alert.party_away":            "** Cannot leave orbit with the landing party away! **",
+	"alert.probe_destroyed":       "** Klingons have destroyed the probe in quadrant %d, %d **",
+	"alert.probe_supernova":       "** The probe sets off a supernova in quadrant %d, %d! **",
+	"alert.ship_wormhole":         "** %s escapes through the wormhole at %d, %d **",
+	"alert.starbase_attack":       "** Starbase in quadrant %d, %d reports it is under attack! **",
+	"alert.starbase_destroyed":    "** Starbase in quadrant %d, %d destroyed by a Klingon Commander! **",
+	"alert.supernova":             "** Subspace radio: supernova in quadrant %d, %d! **",
+	"alert.tholian":               "A Tholian ship has appeared at %d, %d!",
+	"alert.trapped":               "** The Tholian web is complete!  The Enterprise is trapped! **",
+	"alert.warp_full":             "** No room in that quadrant for the Enterprise! **",
+	"alert.warp_shields":          "** Cannot go to warp with shields raised! **",
+	"alert.warp_supernova":        "** Cannot warp into a supernova! **",
+	"alert.web_holds":             "** The Tholian web holds the Enterprise fast! **",
+	"alert.wormhole_full":         "** No room beyond the wormhole!  The Enterprise pulls back **",
+	"alert.wormhole_supernova":    "** The wormhole leads into a supernova!  The Enterprise pulls back **",
+	"arc.aft":                     "aft",
+	"arc.fore":                    "fore",
+	"arc.port":                    "port",
+	"arc.starboard":               "starboard",
+	"campaign.casualties":         "Crew lost over the campaign: %d",
+	"campaign.complete":           "Campaign complete!  Starfleet Command commends you and your crew.",
+	"campaign.mission":            "Mission %d of %d: %s",
+	"campaign.objectives":         "OBJECTIVES",
+	"campaign.save_failed":        "** Unable to save the campaign: %v **",
+	"campaign.ship":               "Energy: %d   Shields: %d   Torpedoes: %d",
+	"classic.bad_course":          "   LT. SULU REPORTS, 'INCORRECT COURSE DATA, SIR!'",
+	"classic.bad_torpedo_course":  "ENSIGN CHEKOV REPORTS,  'INCORRECT COURSE DATA, SIR!'",
+	"classic.bad_warp":            "   CHIEF ENGINEER SCOTT REPORTS 'THE ENGINES WON'T TAKE WARP %s!'",
+	"classic.bearing":             "%s AT %d,%d:  DIRECTION = %d  DISTANCE = %.1f  (%s)",
+	"classic.command":             "COMMAND",
+	"classic.computer":            "COMPUTER ACTIVE AND AWAITING COMMAND",
+	"classic.course":              "COURSE (1-9)",
+	"classic.damage":              "DAMAGE CONTROL REPORT:",
+	"classic.damage.crew":         "  CREW                %d OF %d  (%d LOST)",
+	"classic.damage.efficiency":   "  EFFICIENCY          %d%%",
+	"classic.damage.life_support": "  LIFE SUPPORT        %.1f STARDATES LEFT",
+	"classic.damage.morale":       "  MORALE              %d%%",
+	"classic.damage.power":        "  POWER E/S/W         %d/%d/%d",
+	"classic.damage.shields":      "  SHIELDS F/S/A/P     %d/%d/%d/%d",
+	"classic.deflector":           "DEFLECTOR CONTROL ROOM REPORT:",
+	"classic.destroyed":           "THE ENTERPRISE HAS BEEN DESTROYED.  THE FEDERATION WILL BE CONQUERED.",
+	"classic.edge":                "   LT. UHURA REPORTS THE ENTERPRISE IS AT THE EDGE OF THE GALAXY",
+	"classic.end_of_mission":      "THE END OF YOUR MISSION.",
+	"classic.energy_available":    "ENERGY AVAILABLE = %d",
+	"classic.enter_one":           "ENTER ONE OF THE FOLLOWING:",
+	"classic.entering":            "NOW ENTERING QUADRANT %d,%d",
+	"classic.functions":           "FUNCTIONS AVAILABLE FROM LIBRARY-COMPUTER:\n   0 = CUMULATIVE GALACTIC RECORD\n   1 = STATUS REPORT\n   2 = PHOTON TORPEDO DATA\n   3 = STARBASE NAV DATA",
+	"classic.help.com":            "TO CALL ON LIBRARY-COMPUTER",
+	"classic.help.dam":            "FOR DAMAGE CONTROL REPORTS",
+	"classic.help.lrs":            "FOR LONG RANGE SENSOR SCAN",
+	"classic.help.nav":            "TO SET COURSE",
+	"classic.help.pha":            "TO FIRE PHASERS",
+	"classic.help.she":            "TO RAISE OR LOWER SHIELDS",
+	"classic.help.srs":            "FOR SHORT RANGE SENSOR SCAN",
+	"classic.help.tor":            "TO FIRE PHOTON TORPEDOES",
+	"classic.help.xxx":            "TO RESIGN YOUR COMMAND",
+	"classic.in_line":             "IN LINE",
+	"classic.klingon":             "KLINGON",
+	"classic.klingons_left":       "KLINGONS LEFT:  %d",
+	"classic.left":                "THERE WERE %d KLINGON BATTLE CRUISERS LEFT AT",
+	"classic.lrs":                 "LONG RANGE SCAN FOR QUADRANT %d,%d",
+	"classic.maintaining":         "THE FEDERATION IS MAINTAINING %d STARBASES IN THE GALAXY",
+	"classic.no_torpedoes":        "ALL PHOTON TORPEDOES EXPENDED",
+	"classic.none_found":          "SCIENCE OFFICER SPOCK REPORTS  'SENSORS SHOW NO %sS IN THIS QUADRANT.'",
+	"classic.off_line":            "OFF THE LINE OF FIRE",
+	"classic.orders":              "YOUR ORDERS ARE AS FOLLOWS:",
+	"classic.orders_course":       "YOUR COURSE IS GIVEN AS ON A NUMERIC KEYPAD: 8 IS UP, 6 IS RIGHT, AND SO ON.",
+	"classic.orders_destroy":      "     DESTROY THE %d KLINGON WARSHIPS WHICH HAVE INVADED",
+	"classic.orders_galaxy":       "   THE GALAXY BEFORE THEY CAN ATTACK FEDERATION HEADQUARTERS",
+	"classic.orders_help":         "TYPE HELP FOR A LIST OF COMMANDS.",
+	"classic.orders_starbases":    "   THERE ARE %d STARBASES IN THE GALAXY FOR RESUPPLYING YOUR SHIP.",
+	"classic.orders_time":         "   ON STARDATE %.1f.  THIS GIVES YOU %.1f DAYS.",
+	"classic.out_of_time":         "YOU HAVE RUN OUT OF TIME, AND THE KLINGONS HOLD THE FIELD.",
+	"classic.phasers":             "PHASERS LOCKED ON TARGET;  ENERGY AVAILABLE = %d UNITS",
+	"classic.record":              "COMPUTER RECORD OF GALAXY",
+	"classic.shields_now":         "  'SHIELDS NOW AT %d UNITS PER YOUR COMMAND.'",
+	"classic.shields_unchanged":   "<SHIELDS UNCHANGED>",
+	"classic.shut_down":           "WARP ENGINES SHUT DOWN AT SECTOR %d,%d DUE TO BAD NAVIGATION",
+	"classic.srs.condition":       "CONDITION          %s",
+	"classic.srs.crew":            "CREW/MORALE        %d/%d%%",
+	"classic.srs.energy":          "TOTAL ENERGY       %d",
+	"classic.srs.heading":         "HEADING            %s",
+	"classic.srs.klingons":        "KLINGONS REMAINING %d",
+	"classic.srs.quadrant":        "QUADRANT           %d,%d",
+	"classic.srs.sector":          "SECTOR             %d,%d",
+	"classic.srs.shields":         "SHIELDS            %d",
+	"classic.srs.stardate":        "STARDATE           %.1f",
+	"classic.srs.torpedoes":       "PHOTON TORPEDOES   %d",
+	"classic.starbase":            "STARBASE",
+	"classic.starbases_lost":      "WITH ONLY %d STARBASES LEFT, STARFLEET CAN NO LONGER HOLD THE LINE.",
+	"classic.stardate":            "IT IS STARDATE %.1f",
+	"classic.status":              "   STATUS REPORT:",
+	"classic.time_left":           "MISSION MUST BE COMPLETED IN %.1f STARDATES",
+	"classic.torpedo_course":      "PHOTON TORPEDO COURSE (1-9)",
+	"classic.units_fire":          "NUMBER OF UNITS TO FIRE",
+	"classic.units_shields":       "NUMBER OF UNITS TO SHIELDS",
+	"classic.warp":                "WARP FACTOR (0.1-8)",
+	"classic.won":                 "CONGRATULATIONS, CAPTAIN!  THE LAST KLINGON BATTLE CRUISER\nMENACING THE FEDERATION HAS BEEN DESTROYED.",
+	"combat.destroyed":            "%s at %d, %d destroyed!",
+	"combat.enemy_torpedo":        "%s at %d, %d is firing a torpedo!",
+	"combat.enterprise_hit":       "Enterprise took %d damage on the %s shields from a %s",
+	"combat.hit":                  "%s at %d, %d took %d damage from a %s",
+	"combat.phasers_fired":        "Phasers fired with %d units of energy!",
+	"combat.romulan_plasma":       "Romulan decloaks at %d, %d and fires a plasma torpedo!",
+	"combat.torpedo_black_hole":   "Torpedo swallowed by the black hole at %d, %d",
+	"combat.torpedo_fired":        "Torpedo fired!",
+	"combat.torpedo_through":      "Torpedo passes through the wormhole at %d, %d into quadrant %d, %d",
+	"combat.torpedo_wormhole":     "Torpedo vanished into the wormhole at %d, %d",
+	"condition.DOCKED":            "DOCKED",
+	"condition.GREEN":             "GREEN",
+	"condition.RED":               "RED",
+	"condition.YELLOW":            "YELLOW",
+	"debrief.completed":           "On Stardate %.1f, the Enterprise completed its mission.",
+	"debrief.title":               "MISSION DEBRIEF: %s",
+	"filter.ALERTS":               "ALERTS",
+	"filter.ALL":                  "ALL",
+	"filter.COMBAT":               "COMBAT",
+	"galaxy.legend":               "Each quadrant shows KLINGONS, ROMULANS, STARBASES, PLANETS and STARS\nQuadrants marked ***** have been destroyed by a supernova\nReadings marked ? are over %d stardates old, and probes show as <.....>",
+	"galaxy.summary":              "STARDATE: %.1f     KLINGONS: %d     STARBASES: %d",
+	"galaxy.title":                "GALAXY MAP",
+	"heading.E":                   "E",
+	"heading.N":                   "N",
+	"heading.NE":                  "NE",
+	"heading.NW":                  "NW",
+	"heading.S":                   "S",
+	"heading.SE":                  "SE",
+	"heading.SW":                  "SW",
+	"heading.W":                   "W",
+	"log.header":                  "-- MESSAGES: %s --  PgUp/PgDn to scroll  (F)ilter",
+	"log.line":                    "Stardate %.1f: %s",
+	"loss.destroyed":              "The Enterprise was destroyed on Stardate %.1f with all hands lost.",
+	"loss.ignominious":            "You suffered an ignominious defeat, failing to destroy even one enemy ship.\nYour humiliation is lessened only by the fact that your ship was destroyed,\nlost with all of its crew - including you!\nYour tactics will be studied through the ages as an example of\nhow not to conduct a war!",
+	"loss.out_of_time":            "Stardate %.1f has come and gone, and the Klingons still hold the field.\n\nStarfleet Command has relieved you of your command.",
+	"map.quadrant":                "Quadrant : %d, %d",
+	"menu.normal":                 "(N)avigation (W)eapons (S)hields (A)llocate (L)R Sensors (C)omputer (P)lanet",
+	"menu.planets":                "(O)rbit  (B)eam down party  (T)ake shuttle  (R)ecall party  (D)ilithium boost",
+	"menu.shields":                "(R)aise or lower all  (B)alance  (F)ore  (A)ft  (P)ort  (S)tarboard",
+	"menu.weapons":                "(P)hasers, Photon (T)orpedoes, (L)aunch a probe or (A)rmed probe",
+	"msg.already_orbiting":        "We are already in orbit",
+	"msg.beamed_down":             "Landing party beamed down to the planet",
+	"msg.clock_fastest":           "The game is already at its fastest",
+	"msg.clock_slowest":           "The game is already at its slowest",
+	"msg.clock_speed":             "Game speed %gx",
+	"msg.clock_stepped":           "Clock stopped: press . to step, + or - to run again",
+	"msg.dilithium_boost":         "Dilithium crystals burned for %d energy",
+	"msg.dilithium_exhausted":     "Landing party reports the last of the dilithium has been mined",
+	"msg.no_crystals":             "There are no dilithium crystals aboard",
+	"msg.no_planet":               "There is no planet close enough to orbit",
+	"msg.no_targets":              "There is nothing for the phasers to lock on to",
+	"msg.not_orbiting":            "We must be in orbit to send a landing party",
+	"msg.orbit":                   "Standard orbit established around the %s",
+	"msg.party_already_away":      "The landing party is already away",
+	"msg.party_not_down":          "The landing party is not on the planet",
+	"msg.phaser_energy":           "You do not have enough energy for that",
+	"msg.power_digits":            "Give three digits: power to engines, shields and weapons",
+	"msg.power_set":               "Power set: engines %d, shields %d, weapons %d",
+	"msg.power_total":             "Power must add up to %d units",
+	"msg.probe_dud":               "The probe detonates in quadrant %d, %d, but there is no star there to set off",
+	"msg.probe_launched":          "Probe launched on course %d",
+	"msg.probe_left":              "The probe has left the galaxy",
+	"msg.shields_balanced":        "Shields balanced",
+	"msg.shuttle_landed":          "Shuttlecraft has landed on the planet",
+	"msg.shuttle_launched":        "Shuttlecraft launched for the planet",
+	"msg.shuttle_returning":       "Shuttlecraft lifting off for the Enterprise",
+	"msg.transporter_energy":      "Not enough energy for the transporter",
+	"msg.transporter_shields":     "Cannot use the transporter with shields raised",
+	"msg.warp_energy":             "You do not have enough energy for that trip",
+	"msg.web_dissolves":           "The Tholian web dissolves",
+	"msg.wormhole":                "The Enterprise emerges from a wormhole in quadrant %d, %d",
+	"msg.wormhole_exit":           "The Enterprise emerges from the wormhole at %d, %d",
+	"name.blackhole":              "Black hole",
+	"name.commander":              "Klingon Commander",
+	"name.enterprise":             "Enterprise",
+	"name.klingon":                "Klingon",
+	"name.planet":                 "Class %s planet",
+	"name.romulan":                "Romulan",
+	"name.star":                   "Star",
+	"name.starbase":               "Starbase",
+	"name.super":                  "Klingon Super-Commander",
+	"name.tholian":                "Tholian",
+	"name.web":                    "Tholian web",
+	"name.wormhole":               "Wormhole",
+	"prompt.begin_or_quit":        "Press ENTER to begin or ESC to quit",
+	"prompt.continue_or_quit":     "Press ENTER to continue or ESC to quit",
+	"prompt.direction":            "Direction: ",
+	"prompt.esc_quit":             "Press ESC to quit",
+	"prompt.phasers":              "Energy to fire from phasers (%d available): ",
+	"prompt.power":                "Power to engines, shields, weapons (%d in all, e.g. 333): ",
+	"prompt.probe_course":         "Probe course (%d left): ",
+	"prompt.probe_range":          "Quadrants to the target: ",
+	"prompt.quadrant_x":           "Destination Quadrant X:",
+	"prompt.quadrant_y":           "Destination Quadrant Y:",
+	"prompt.quit":                 "Do you wish to quit (Y/N)?",
+	"prompt.retry":                "Press ENTER to fly the mission again or ESC to quit",
+	"prompt.shield_arc":           "Set energy for %s shields: ",
+	"prompt.shields":              "Set energy for shields: ",
+	"scan.title":                  "LONG RANGE SCAN",
+	"screen.too_small":            "Terminal too small\nIt is %d by %d, and needs to be at least %d by %d",
+	"stats.casualties":            "Crew casualties:    %5d",
+	"stats.commanders":            "Commanders destroyed: %d of %d",
+	"stats.klingons":              "Klingons destroyed: %5d of %d",
+	"stats.starbases":             "Starbases lost:     %5d of %d",
+	"stats.super_at_large":        "The Super-Commander is still at large.",
+	"stats.super_destroyed":       "The Super-Commander was destroyed.",
+	"stats.time":                  "Time taken:         %5.1f stardates",
+	"status.condition":            "CONDITION:",
+	"status.crew":                 "CREW/MORALE:",
+	"status.dilithium":            "DILITHIUM:",
+	"status.energy":               "ENERGY:",
+	"status.heading":              "HEADING:",
+	"status.klingons":             "KLINGONS:",
+	"status.life_support":         "LIFE SUPPORT:",
+	"status.orbit":                "IN STANDARD ORBIT",
+	"status.party":                "PARTY ON PLANET (%d)",
+	"status.paused":               "PAUSED",
+	"status.power":                "POWER E/S/W:",
+	"status.sector":               "SECTOR:",
+	"status.shields":              "SHIELDS:",
+	"status.shuttle":              "SHUTTLECRAFT IN FLIGHT",
+	"status.stardate":             "STARDATE:",
+	"status.torpedoes":            "PHOTON TORPEDOES:",
+	"tooltip.object":              "%s at %d,%d",
+	"tooltip.shields":             "  shields %d",
+	"weapon.nova":                 "nova",
+	"weapon.phaser":               "phaser blast",
+	"weapon.plasma":               "plasma torpedo",
+	"weapon.torpedo":              "torpedo",
+	"win.last_ship":               "On Stardate %.1f, the Enterprise successfully destroyed the\nlast Klingon ship and won the war.",
+	"win.promoted":                "It took %.1f Stardates to win the war.\nThis is an average of %.1f Stardates per enemy.\nStarfleet Command congratulates you on your victory, and you\nare hereby promoted to Admiral.",
+	"win.will_to_fight":           "On Stardate %.1f, the Enterprise successfully destroyed the\nKlingon fleet's will to fight and won the war.",
+}
+
+// englishPlurals are the English messages that depend on a number
+var englishPlurals = map[string]map[string]string{
+	"campaign.record":    {"one": "%-28s %5.1f stardates  %2d Klingons  %d attempt", "other": "%-28s %5.1f stardates  %2d Klingons  %d attempts"},
+	"combat.probe_armed": {"one": "Armed probe launched on course %d, to detonate %d quadrant out", "other": "Armed probe launched on course %d, to detonate %d quadrants out"},
+	"log.newer":          {"one": "  (%d newer)", "other": "  (%d newer)"},
+	"loss.annals":        {"one": "While you managed to defeat %d enemy, the remaining %d Klingons destroyed\nall the Starbases and won the war!\nYour defeat will go down in the annals of history!", "other": "While you managed to defeat %d enemies, the remaining %d Klingons destroyed\nall the Starbases and won the war!\nYour defeat will go down in the annals of history!"},
+	"loss.starbases":     {"one": "On Stardate %.1f, with only %d starbase left,\nStarfleet could no longer hold the line and the war was lost.", "other": "On Stardate %.1f, with only %d starbases left,\nStarfleet could no longer hold the line and the war was lost."},
+	"loss.tally":         {"one": "You destroyed %d of %d Klingon ship.", "other": "You destroyed %d of %d Klingon ships."},
+	"msg.party_aboard":   {"one": "Landing party aboard with %d dilithium crystal", "other": "Landing party aboard with %d dilithium crystals"},
+}
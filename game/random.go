@@ -5,20 +5,51 @@ import (
 	"time"
 )
 
+// Random is a source of random numbers for a single game,
+// so that games can be seeded and run side by side
+type Random struct {
+	rnd *rand.Rand
+}
+
+// NewRandom creates a new random source from the given seed
+func NewRandom(seed int64) *Random {
+	return &Random{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// RandomInt returns a random int between 0 and n-1, inclusive
+func (r *Random) RandomInt(n int) int {
+	return r.rnd.Intn(n)
+}
+
+// CheckPercent returns true/false based on random number weighted by percentage
+func (r *Random) CheckPercent(percentage int) bool {
+	return r.rnd.Intn(100) < percentage
+}
+
+// GetPercent returns a number between 0 and 99
+func (r *Random) GetPercent() int {
+	return r.rnd.Intn(100)
+}
+
 // Rnd is global random
-var rnd *rand.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+var rnd = NewRandom(time.Now().UnixNano())
 
 // RandomInt returns a random int between 0 and n-1, inclusive
 func RandomInt(n int) int {
-	return rnd.Intn(n)
+	return rnd.RandomInt(n)
 }
 
 // CheckPercent returns true/false based on random number weighted by percentage
 func CheckPercent(percentage int) bool {
-	return rnd.Intn(100) < percentage
+	return rnd.CheckPercent(percentage)
 }
 
 // GetPercent returns a number between 0 and 99
 func GetPercent() int {
-	return rnd.Intn(100)
+	return rnd.GetPercent()
+}
+
+// NewSeed returns a seed for a new game
+func NewSeed() int64 {
+	return time.Now().UnixNano()
 }
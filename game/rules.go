@@ -0,0 +1,191 @@
+package game
+
+import "fmt"
+
+// standardArea is the number of quadrants in the standard
+// 8x8 galaxy, which the numbers in the rules are meant for
+const standardArea = 8 * 8
+
+// Rules holds the tunable numbers that drive the game's balance
+type Rules struct {
+	// Galaxy is the size of the galaxy in quadrants, and
+	// Quadrant the size of each quadrant in sectors
+	Galaxy   Dimensions
+	Quadrant Dimensions
+
+	// KlingonDistribution is the cumulative percentage chance that
+	// a quadrant is given 0, 1, 2... Klingons.  Anything past the
+	// last entry gets one more than the length of the list.
+	KlingonDistribution []int
+
+	// KlingonActionPercent is the chance a Klingon does anything on a turn
+	KlingonActionPercent int
+
+	// KlingonFirePercent is the chance an active Klingon fires instead of moving
+	KlingonFirePercent int
+
+	// TorpedoDamage is the damage done by a single photon torpedo
+	TorpedoDamage int
+
+	// Romulans is the number of Romulan warships in the galaxy
+	Romulans int
+
+	// RomulanActionPercent is the chance a cloaked Romulan does anything on a turn
+	RomulanActionPercent int
+
+	// Commanders and SuperCommanders are the number of each
+	// in the galaxy, on top of the ordinary Klingons
+	Commanders      int
+	SuperCommanders int
+
+	// CommanderActionPercent is the chance a Commander does anything on a turn
+	CommanderActionPercent int
+
+	// CommanderFollowPercent is the chance a Commander follows the Enterprise when it warps out
+	CommanderFollowPercent int
+
+	// CommanderBaseDamage is the damage a Commander does to a starbase each stardate
+	CommanderBaseDamage int
+
+	// Planets is the number of planets in the galaxy
+	Planets int
+
+	// TholianPercent is the chance a Tholian turns up when
+	// the Enterprise warps into a quadrant
+	TholianPercent int
+
+	// NovaPercent is the chance a photon torpedo sets off the
+	// star it hits, NovaChainPercent the chance a nova sets off
+	// a star next to it, and NovaDamage what it does to ships
+	// and starbases alongside
+	NovaPercent      int
+	NovaChainPercent int
+	NovaDamage       int
+
+	// SupernovaPercent is the chance each stardate that a star
+	// somewhere in the galaxy goes supernova
+	SupernovaPercent int
+
+	// BlackHoles and Wormholes are the number of black holes
+	// and wormhole pairs in the galaxy
+	BlackHoles int
+	Wormholes  int
+
+	// CrystalEnergy is the energy each dilithium crystal gives
+	// in an emergency boost, and CrystalRiskPercent the chance
+	// of the boost going wrong, which rises with every use
+	CrystalEnergy      int
+	CrystalRiskPercent int
+
+	// PlasmaDamage and PlasmaRange describe a Romulan plasma torpedo
+	PlasmaDamage int
+	PlasmaRange  int
+
+	// ProbeTurns is how many turns a probe takes to cross a
+	// quadrant, and ProbeLossPercent the chance each Klingon in
+	// a quadrant it passes through shoots it down
+	ProbeTurns       int
+	ProbeLossPercent int
+
+	// KlingonRegenPercent is how much of their full shields
+	// Klingons recharge each stardate the Enterprise is away
+	KlingonRegenPercent int
+
+	// ActionTurns is how many turns, each a tenth of a stardate,
+	// every kind of order takes in turn-based play
+	ActionTurns map[int]int
+
+	// StaleScan is the number of stardates after which the galaxy
+	// map marks what was last seen of a quadrant as out of date
+	StaleScan int
+}
+
+// DefaultRules returns the standard game rules
+func DefaultRules() *Rules {
+	return &Rules{
+		Galaxy:                 Dimensions{Width: 8, Height: 8},
+		Quadrant:               Dimensions{Width: 10, Height: 10},
+		KlingonDistribution:    []int{55, 75, 85, 92, 97},
+		KlingonActionPercent:   66,
+		KlingonFirePercent:     25,
+		TorpedoDamage:          500,
+		Romulans:               6,
+		Commanders:             3,
+		SuperCommanders:        1,
+		CommanderActionPercent: 40,
+		CommanderFollowPercent: 50,
+		CommanderBaseDamage:    2500,
+		RomulanActionPercent:   50,
+		Planets:                12,
+		TholianPercent:         10,
+		NovaPercent:            20,
+		NovaChainPercent:       50,
+		NovaDamage:             1000,
+		SupernovaPercent:       5,
+		BlackHoles:             4,
+		Wormholes:              2,
+		CrystalEnergy:          500,
+		CrystalRiskPercent:     10,
+		PlasmaDamage:           600,
+		PlasmaRange:            3,
+		ProbeTurns:             5,
+		ProbeLossPercent:       20,
+		KlingonRegenPercent:    10,
+		StaleScan:              3,
+		ActionTurns: map[int]int{
+			ActionMove:    1,
+			ActionWarp:    5,
+			ActionTorpedo: 1,
+			ActionPhasers: 1,
+			ActionShields: 1,
+			ActionPower:   1,
+			ActionPlanet:  2,
+			ActionProbe:   1,
+		},
+	}
+}
+
+// KlingonsForQuadrant picks how many Klingons to place in a quadrant
+func (r *Rules) KlingonsForQuadrant(rnd *Random) int {
+	n := rnd.GetPercent()
+	for i, p := range r.KlingonDistribution {
+		if n < p {
+			return i
+		}
+	}
+	return len(r.KlingonDistribution)
+}
+
+// CheckSizes reports an error if the galaxy or its quadrants
+// are too small or too big to play in
+func (r *Rules) CheckSizes() error {
+	if !r.Galaxy.Within(MinGalaxySize, MaxGalaxySize) {
+		return fmt.Errorf("galaxy size %s must be between %d and %d quadrants each way", r.Galaxy, MinGalaxySize, MaxGalaxySize)
+	}
+	if !r.Quadrant.Within(MinQuadrantSize, MaxQuadrantSize) {
+		return fmt.Errorf("quadrant size %s must be between %d and %d sectors each way", r.Quadrant, MinQuadrantSize, MaxQuadrantSize)
+	}
+	return nil
+}
+
+// Resize changes the size of the galaxy and its quadrants,
+// scaling the numbers of Romulans, Commanders, planets, black
+// holes and wormholes, taken as being for the standard 8x8
+// galaxy, to the new galaxy's area
+func (r *Rules) Resize(galaxy, quadrant Dimensions) {
+	r.Galaxy, r.Quadrant = galaxy, quadrant
+	for _, n := range []*int{&r.Romulans, &r.Commanders, &r.SuperCommanders, &r.Planets, &r.BlackHoles, &r.Wormholes} {
+		*n = r.Scale(*n)
+	}
+}
+
+// Scale scales a number meant for the standard 8x8 galaxy to
+// the area of this galaxy, keeping at least one of anything
+// there was some of
+func (r *Rules) Scale(n int) int {
+	result := (n*r.Galaxy.Area() + standardArea/2) / standardArea
+	if result == 0 && n > 0 {
+		return 1
+	}
+	return result
+}
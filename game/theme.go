@@ -0,0 +1,216 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Elements are the parts of the display a theme can colour
+var Elements = []string{
+	"text", "border",
+	"enterprise", "klingon", "commander", "romulan", "tholian", "web",
+	"starbase", "star", "planet", "blackhole", "wormhole",
+	"torpedo", "plasma",
+	"red", "yellow", "green", "docked",
+	"info", "combat", "alert",
+}
+
+// Theme is a palette for the display, and a choice of
+// ASCII or Unicode glyphs for the sector map
+type Theme struct {
+	Name       string            `json:"name"`
+	Base       string            `json:"base"`
+	Unicode    bool              `json:"unicode"`
+	Background string            `json:"background"`
+	Colours    map[string]string `json:"colours"`
+
+	styles map[string]tcell.Style
+}
+
+// Themes are the built-in palettes
+var Themes = map[string]*Theme{
+	"dark": {
+		Name:       "dark",
+		Background: "black",
+		Colours: map[string]string{
+			"text": "white", "border": "silver",
+			"enterprise": "bold white", "klingon": "red", "commander": "bold red", "romulan": "lime",
+			"tholian": "fuchsia", "web": "purple", "starbase": "aqua", "star": "yellow", "planet": "green",
+			"blackhole": "gray", "wormhole": "fuchsia", "torpedo": "yellow", "plasma": "lime",
+			"red": "red", "yellow": "yellow", "green": "lime", "docked": "aqua",
+			"info": "white", "combat": "yellow", "alert": "red",
+		},
+	},
+	"light": {
+		Name:       "light",
+		Background: "white",
+		Colours: map[string]string{
+			"text": "black", "border": "gray",
+			"enterprise": "bold black", "klingon": "maroon", "commander": "bold maroon", "romulan": "green",
+			"tholian": "purple", "web": "purple", "starbase": "teal", "star": "olive", "planet": "green",
+			"blackhole": "black", "wormhole": "purple", "torpedo": "darkorange", "plasma": "green",
+			"red": "red", "yellow": "darkorange", "green": "green", "docked": "teal",
+			"info": "black", "combat": "darkorange", "alert": "red",
+		},
+	},
+	"high-contrast": {
+		Name:       "high-contrast",
+		Background: "black",
+		Colours: map[string]string{
+			"text": "bold white", "border": "bold white",
+			"enterprise": "bold white", "klingon": "bold red", "commander": "bold red", "romulan": "bold lime",
+			"tholian": "bold fuchsia", "web": "bold fuchsia", "starbase": "bold aqua", "star": "bold yellow", "planet": "bold lime",
+			"blackhole": "bold white", "wormhole": "bold fuchsia", "torpedo": "bold yellow", "plasma": "bold lime",
+			"red": "bold red", "yellow": "bold yellow", "green": "bold lime", "docked": "bold aqua",
+			"info": "bold white", "combat": "bold yellow", "alert": "bold red",
+		},
+	},
+	// Okabe and Ito's palette, which stays distinct with
+	// the common kinds of colour blindness
+	"colour-blind": {
+		Name:       "colour-blind",
+		Background: "black",
+		Colours: map[string]string{
+			"text": "white", "border": "silver",
+			"enterprise": "bold white", "klingon": "#D55E00", "commander": "bold #D55E00", "romulan": "#009E73",
+			"tholian": "#CC79A7", "web": "#CC79A7", "starbase": "#56B4E9", "star": "#F0E442", "planet": "#009E73",
+			"blackhole": "gray", "wormhole": "#CC79A7", "torpedo": "#F0E442", "plasma": "#E69F00",
+			"red": "#D55E00", "yellow": "#F0E442", "green": "#56B4E9", "docked": "#0072B2",
+			"info": "white", "combat": "#E69F00", "alert": "#D55E00",
+		},
+	},
+}
+
+var theme = mustCompile(Themes["dark"])
+
+// ThemeNames lists the built-in themes
+func ThemeNames() []string {
+	var result []string
+	for name := range Themes {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// LoadTheme returns the built-in theme with the given name,
+// or else reads a theme file.  A theme file only needs the
+// colours that differ from its base theme, dark by default.
+func LoadTheme(name string) (*Theme, error) {
+	if t, ok := Themes[name]; ok {
+		result := *t
+		return &result, compile(&result)
+	}
+
+	data, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no theme called %q, expected one of %s or a theme file", name, strings.Join(ThemeNames(), ", "))
+	} else if err != nil {
+		return nil, err
+	}
+	result := &Theme{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	if result.Base == "" {
+		result.Base = "dark"
+	}
+	base, ok := Themes[result.Base]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown base theme %q, expected one of %s", name, result.Base, strings.Join(ThemeNames(), ", "))
+	}
+	if result.Background == "" {
+		result.Background = base.Background
+	}
+	colours := map[string]string{}
+	for e, c := range base.Colours {
+		colours[e] = c
+	}
+	for e, c := range result.Colours {
+		colours[e] = c
+	}
+	result.Colours = colours
+
+	if err := compile(result); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return result, nil
+}
+
+func mustCompile(t *Theme) *Theme {
+	if err := compile(t); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// compile turns the theme's colour names into styles
+func compile(t *Theme) error {
+	bg, err := parseColour(t.Background)
+	if err != nil {
+		return err
+	}
+
+	known := map[string]bool{}
+	for _, e := range Elements {
+		known[e] = true
+	}
+
+	t.styles = map[string]tcell.Style{}
+	for e, c := range t.Colours {
+		if !known[e] {
+			return fmt.Errorf("unknown element %q", e)
+		}
+		style := tcell.StyleDefault.Background(bg)
+		if strings.HasPrefix(c, "bold ") {
+			style = style.Bold(true)
+			c = strings.TrimPrefix(c, "bold ")
+		}
+		fg, err := parseColour(c)
+		if err != nil {
+			return fmt.Errorf("%s: %v", e, err)
+		}
+		t.styles[e] = style.Foreground(fg)
+	}
+	return nil
+}
+
+func parseColour(name string) (tcell.Color, error) {
+	if name == "" || name == "default" {
+		return tcell.ColorDefault, nil
+	}
+	c := tcell.GetColor(strings.ToLower(name))
+	if c == tcell.ColorDefault {
+		return c, fmt.Errorf("unknown colour %q", name)
+	}
+	return c, nil
+}
+
+// SetTheme changes the theme the display is drawn in
+func SetTheme(t *Theme) {
+	theme = t
+	if scr != nil {
+		scr.SetStyle(Style("text"))
+	}
+}
+
+// CurrentTheme returns the theme the display is drawn in
+func CurrentTheme() *Theme {
+	return theme
+}
+
+// Style returns the theme's style for an element of
+// the display, or the text style if it has none
+func Style(element string) tcell.Style {
+	if s, ok := theme.styles[element]; ok {
+		return s
+	}
+	return theme.styles["text"]
+}
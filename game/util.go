@@ -8,3 +8,27 @@ func Distance(x1, y1, x2, y2 int) float64 {
 	second := math.Pow(float64(y2-y1), 2)
 	return math.Sqrt(first + second)
 }
+
+// Abs returns the absolute value of n
+func Abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Max returns the larger of a and b
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Min returns the smaller of a and b
+func Min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -1,36 +1,142 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
-	"time"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/hculpan/kabtrek/campaign"
+	"github.com/hculpan/kabtrek/classic"
 	"github.com/hculpan/kabtrek/galaxy"
 	"github.com/hculpan/kabtrek/game"
 	"github.com/hculpan/kabtrek/quadrant"
+	"github.com/hculpan/kabtrek/scenario"
 )
 
 // This program just prints "Hello, World!".  Press ESC to exit.
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		os.Exit(runSimulate(os.Args[2:]))
+	}
+
+	scenarioFile := flag.String("scenario", "", "JSON scenario file to play instead of a random galaxy")
+	campaignFile := flag.String("campaign", "", "JSON campaign file to play")
+	saveFile := flag.String("save", "", "campaign save file (defaults to one next to the campaign file)")
+	themeName := flag.String("theme", "dark", "colour theme: "+strings.Join(game.ThemeNames(), ", ")+", or a JSON theme file")
+	unicode := flag.Bool("unicode", false, "draw the sector map with Unicode symbols")
+	single := flag.Bool("single", false, "use the single screen display even on a wide terminal")
+	classicMode := flag.Bool("classic", false, "play with typed commands and text reports, without the full screen display")
+	galaxySize := flag.String("galaxy", "8x8", "size of the galaxy in quadrants, as WxH")
+	quadrantSize := flag.String("quadrant", "10x10", "size of each quadrant in sectors, as WxH")
+	turnBased := flag.Bool("turns", false, "play turn by turn, with the game waiting for each order")
+	lang := flag.String("lang", "", "language of the game's text, such as de, or a JSON catalogue file (defaults to $KABTREK_LANG or $LANG)")
+	flag.Parse()
+
+	if err := setLanguage(*lang); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	t, err := game.LoadTheme(*themeName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *unicode {
+		t.Unicode = true
+	}
+	game.SetTheme(t)
+	game.SetDashboard(!*single)
+
+	if *campaignFile != "" {
+		if *classicMode {
+			fmt.Fprintln(os.Stderr, "campaigns can't be played with --classic")
+			os.Exit(1)
+		}
+		os.Exit(runCampaign(*campaignFile, *saveFile, *turnBased))
+	}
+
+	var g *galaxy.Galaxy
+	if *scenarioFile != "" {
+		s, err := scenario.Load(*scenarioFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		g = s.Build(game.DefaultRules(), game.NewRandom(game.NewSeed()))
+		if s.Name != "" {
+			g.GetActiveQuadrant().AddMessage(s.Name)
+		}
+	} else {
+		rules, err := sizedRules(*galaxySize, *quadrantSize)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		g = galaxy.NewGalaxyWithRules(rules.Scale(25), rules.Scale(5), rules, game.NewRandom(game.NewSeed()))
+		g.PlacePlayer()
+	}
+
+	if *classicMode {
+		classic.Run(g, os.Stdin, os.Stdout)
+		os.Exit(0)
+	}
+	g.TurnBased = *turnBased
+
 	if err := game.InitScreen(); err != nil {
 		fmt.Fprintf(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 
-	g := galaxy.NewGalaxy(25, 5)
-	g.Player = quadrant.NewEnterprise(game.RandomInt(10), game.RandomInt(10))
-	g.Player.QuadrantX = game.RandomInt(8)
-	g.Player.QuadrantY = game.RandomInt(8)
-	g.SetActiveQuadrant(g.Player.QuadrantX, g.Player.QuadrantY)
-	g.ScanNeighborQuadrants()
-
-	loop(g)
+	ch := game.PollForEvents()
+	if outcome := loop(g, ch); outcome == game.Won {
+		playerWinsDisplay(g, nil, nil, ch)
+	} else if outcome != game.Playing {
+		playerDeadDisplay(g, outcome, nil, nil, ch)
+	}
 
 	game.CloseScreen()
 	os.Exit(0)
 }
 
+// sizedRules returns the default rules for a galaxy of the
+// given sizes, written as WxH
+func sizedRules(galaxySize, quadrantSize string) (*game.Rules, error) {
+	gs, err := game.ParseDimensions(galaxySize)
+	if err != nil {
+		return nil, err
+	}
+	qs, err := game.ParseDimensions(quadrantSize)
+	if err != nil {
+		return nil, err
+	}
+	rules := game.DefaultRules()
+	rules.Resize(gs, qs)
+	return rules, rules.CheckSizes()
+}
+
+// setLanguage loads the catalogue for the language asked for.
+// A language from the environment that the game has no
+// catalogue for is quietly left in English.
+func setLanguage(lang string) error {
+	if lang == "" {
+		c, err := game.LoadCatalogue(game.EnvironmentLocale())
+		if err == nil {
+			game.SetCatalogue(c)
+		}
+		return nil
+	}
+
+	c, err := game.LoadCatalogue(lang)
+	if err != nil {
+		return err
+	}
+	game.SetCatalogue(c)
+	return nil
+}
+
 func handlePanic() {
 	if r := recover(); r != nil {
 		game.CloseScreen()
@@ -45,6 +151,8 @@ func waitForEsc(ch chan tcell.Event) {
 		if len(ch) > 0 {
 			event := <-ch
 			switch ev := event.(type) {
+			case *tcell.EventResize:
+				redrawText()
 			case *tcell.EventKey:
 				if ev.Key() == tcell.KeyESC {
 					t = false
@@ -54,44 +162,58 @@ func waitForEsc(ch chan tcell.Event) {
 	}
 }
 
-func loop(g *galaxy.Galaxy) {
+// waitForContinue waits for ENTER, returning false if
+// ESC is pressed instead
+func waitForContinue(ch chan tcell.Event) bool {
+	for {
+		event := <-ch
+		switch ev := event.(type) {
+		case *tcell.EventResize:
+			redrawText()
+		case *tcell.EventKey:
+			switch ev.Key() {
+			case tcell.KeyEnter:
+				return true
+			case tcell.KeyESC:
+				return false
+			}
+		}
+	}
+}
+
+// loop runs the game until it is over, returning the
+// outcome, or game.Playing if the player quit
+func loop(g *galaxy.Galaxy, ch chan tcell.Event) int {
 	defer handlePanic()
 
+	game.SetMapSizes(g.Rules.Galaxy, g.Rules.Quadrant)
+
 	// Draw initial een
 	g.Draw()
 
-	// Setup event polling thread
-	ch := game.PollForEvents()
-
-	currTime := time.Now()
-	updateCheck := 0
+	g.Clock.Wait()
 	paused := false
+	mouseDown := false
 
 	for {
 		q := g.GetActiveQuadrant()
 
-		if q.IsPlayerDead() {
-			playerDeadDisplay(g, ch)
-			break
-		} else if g.NumberOfKlingons == 0 {
-			playerWinsDisplay(g, ch)
-			break
+		if outcome := g.Outcome(); outcome != game.Playing {
+			return outcome
 		}
 
-		if paused {
-			game.EmitStr(0, 40, "PAUSED")
-		} else {
-			if time.Since(currTime).Seconds() > 0.5 {
-				currTime = time.Now()
-				q.UpdateTorpedoes()
-				g.Draw()
-				updateCheck++
-			}
-			if updateCheck > 1 {
-				g.Update()
-				g.Draw()
-				updateCheck = 0
-			}
+		switch {
+		case game.TooSmall():
+			// Nothing can be seen, so the game waits until
+			// the terminal is big enough again
+		case paused:
+			game.CurrentLayout().Status.Emit(0, 0, game.T("status.paused"))
+			game.ShowScreen()
+		case g.TurnBased:
+			// Nothing moves until the player gives an order
+		case g.Clock.Due():
+			g.Tick()
+			g.Draw()
 		}
 
 		if len(ch) > 0 {
@@ -99,8 +221,21 @@ func loop(g *galaxy.Galaxy) {
 			switch ev := event.(type) {
 			case *tcell.EventResize:
 				g.Draw()
+			case *tcell.EventMouse:
+				x, y := ev.Position()
+				switch {
+				case ev.Buttons()&tcell.Button1 == 0:
+					mouseDown = false
+					g.Hover(x, y)
+				case !mouseDown:
+					// Holding the button down doesn't repeat the click
+					mouseDown = true
+					g.Click(x, y)
+				}
 			case *tcell.EventKey:
-				if ev.Rune() == 32 && !paused {
+				if g.GameState == game.Quadrant && scrollLog(g, ev.Key()) {
+					g.Draw()
+				} else if ev.Rune() == 32 && !paused {
 					paused = true
 				} else if ev.Rune() == 32 {
 					paused = false
@@ -112,18 +247,19 @@ func loop(g *galaxy.Galaxy) {
 
 						num := int(ev.Rune())
 						if num >= 49 && num <= 57 {
-							q.MoveObject(q.Player, num-48)
-							g.Update()
+							g.MovePlayer(num - 48)
 							g.Draw()
 						} else {
 							switch num {
 							case 's', 'S':
-								q.UpdateState(quadrant.Shields)
+								q.UpdateState(quadrant.ShieldsMenu)
 							case 'w', 'W':
 								q.UpdateState(quadrant.Weapons)
+							case 'a', 'A':
+								q.UpdateState(quadrant.Power)
 							case 'n', 'N':
-								if g.Player.Shields > 0 {
-									q.AddMessage("** Cannot go to warp with shields raised! **")
+								if g.Player.Shields() > 0 {
+									q.AddAlert(game.T("alert.warp_shields"))
 								} else {
 									q.UpdateState(quadrant.NavigationX)
 								}
@@ -131,10 +267,21 @@ func loop(g *galaxy.Galaxy) {
 								g.SetGameState(game.LongRangeSensors)
 							case 'c', 'C':
 								g.SetGameState(game.GalaxyMap)
+							case 'p', 'P':
+								q.UpdateState(quadrant.Planets)
+							case 'f', 'F':
+								g.Log.CycleFilter()
+								g.Draw()
+							case 'd', 'D':
+								game.ToggleDashboard()
+								g.Draw()
+							case '+', '=', '-', '.':
+								setClock(g, num)
+								g.Draw()
 							}
 						}
 					} else if g.GameState == game.Quitting && (ev.Rune() == 'y' || ev.Rune() == 'Y') {
-						return
+						return game.Playing
 					} else if g.GameState == game.Quitting && (ev.Rune() == 'n' || ev.Rune() == 'N') {
 						g.SetGameState(game.Quadrant)
 					} else {
@@ -160,93 +307,214 @@ func loop(g *galaxy.Galaxy) {
 					}
 				}
 			}
-			currTime = time.Now()
 		}
 	}
 
 }
 
-func playerWinsDisplay(g game.Game, ch chan tcell.Event) {
+// setClock speeds the game clock up or slows it down, or
+// stops it and steps it on a tick
+func setClock(g *galaxy.Galaxy, key int) {
+	if g.TurnBased {
+		return
+	}
+	q := g.GetActiveQuadrant()
+	switch key {
+	case '+', '=':
+		if g.Clock.Faster() {
+			q.AddMessage(game.T("msg.clock_speed", g.Clock.Speed()))
+		} else {
+			q.AddMessage(game.T("msg.clock_fastest"))
+		}
+	case '-':
+		if g.Clock.Slower() {
+			q.AddMessage(game.T("msg.clock_speed", g.Clock.Speed()))
+		} else {
+			q.AddMessage(game.T("msg.clock_slowest"))
+		}
+	case '.':
+		if g.Clock.Mode != game.Stepped {
+			q.AddMessage(game.T("msg.clock_stepped"))
+		}
+		g.Clock.Step()
+	}
+}
+
+// scrollLog pages the message log up or down, returning
+// false if the key was not for the log
+func scrollLog(g *galaxy.Galaxy, key tcell.Key) bool {
+	page := game.CurrentLayout().Messages.Height - 1
+	switch key {
+	case tcell.KeyPgUp:
+		g.Log.ScrollUp(page)
+	case tcell.KeyPgDn:
+		g.Log.ScrollDown(page)
+	default:
+		return false
+	}
+	return true
+}
+
+// redrawText draws the last text shown again, after
+// the terminal is resized
+var redrawText = func() {}
+
+// displayText draws the lines centred in a box, with the
+// prompt underneath
+func displayText(lines []string, prompt string) {
+	redrawText = func() { displayText(lines, prompt) }
+	if game.TooSmall() {
+		game.DrawTooSmall()
+		return
+	}
 	game.ClearScreen()
 
-	w, _ := game.Size()
-
-	game.DrawBox(15, 2, w-15, 16)
-
-	currentLine := 4
-	msg := fmt.Sprintf("On Stardate %.1f, the Enterprise successfully destroyed the", g.GetStardate())
-	game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-	currentLine++
-	msg = "last Klingon ship and won the war."
-	game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-
-	timeTaken := g.GetStardate() - 3100.1
-	currentLine += 2
-	msg = fmt.Sprintf("It took %.1f Stardates to win the war.", timeTaken)
-	game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-	currentLine++
-	msg = fmt.Sprintf("This is an average of %.1f Stardates per enemy.", timeTaken/float64(g.GetStartingKlingons()))
-	game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-	currentLine++
-	msg = "Starfleet Command congratulates you on your victory, and you"
-	game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-	currentLine++
-	msg = "are hereby promoted to Admiral."
-	game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-
-	currentLine += 2
-	msg = "Press ESC to quit"
-	game.EmitStr(w/2-len(msg)/2, currentLine+2, msg)
+	width := len(prompt)
+	for _, l := range lines {
+		if len(l) > width {
+			width = len(l)
+		}
+	}
+	if width < 44 {
+		width = 44
+	}
+	height := len(lines) + 5
+	if height < 15 {
+		height = 15
+	}
+	r := game.CurrentLayout().Screen.Centre(width+6, height)
+	game.DrawBox(r.X, r.Y, r.X+r.Width-1, r.Y+r.Height-1)
+
+	currentLine := 2
+	for _, msg := range lines {
+		r.EmitCentred(currentLine, msg)
+		currentLine++
+	}
+	r.EmitCentred(currentLine+1, prompt)
 
 	game.ShowScreen()
+}
 
-	waitForEsc(ch)
+// missionStats summarises the mission for a debrief
+func missionStats(g game.Game) []string {
+	return append([]string{
+		game.T("stats.time", g.GetStardate()-g.GetStartingStardate()),
+		game.T("stats.klingons", g.GetStartingKlingons()-g.GetRemainingKlingons(), g.GetStartingKlingons()),
+		game.T("stats.starbases", g.GetStartingStarbases()-g.GetRemainingStarbases(), g.GetStartingStarbases()),
+		game.T("stats.casualties", g.GetCasualties()),
+	}, commanderStats(g)...)
 }
 
-func playerDeadDisplay(g game.Game, ch chan tcell.Event) {
-	game.ClearScreen()
+// commanderStats reports on the Commanders and Super-Commander,
+// if the game had any
+func commanderStats(g game.Game) []string {
+	var lines []string
+	if g.GetStartingCommanders() > 0 {
+		lines = append(lines, game.T("stats.commanders", g.GetStartingCommanders()-g.GetRemainingCommanders(), g.GetStartingCommanders()))
+	}
+	if g.GetStartingSuperCommanders() > 0 {
+		if g.GetRemainingSuperCommanders() == 0 {
+			lines = append(lines, game.T("stats.super_destroyed"))
+		} else {
+			lines = append(lines, game.T("stats.super_at_large"))
+		}
+	}
+	return lines
+}
+
+// playerWinsDisplay shows the victory screen, or the debrief
+// when m is a campaign mission, along with saveErr if the
+// campaign couldn't be saved.  It returns false if the player
+// chose to quit.
+func playerWinsDisplay(g game.Game, m *campaign.Mission, saveErr error, ch chan tcell.Event) bool {
+	var lines []string
+	if m != nil {
+		lines = append(lines,
+			game.T("debrief.title", strings.ToUpper(m.Name)),
+			"",
+			game.T("debrief.completed", g.GetStardate()),
+			"")
+		lines = append(lines, missionStats(g)...)
+		if len(m.Debrief.Success) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, m.Debrief.Success...)
+		}
+		if saveErr != nil {
+			lines = append(lines, "", game.T("campaign.save_failed", saveErr))
+		}
+		displayText(lines, game.T("prompt.continue_or_quit"))
+		return waitForContinue(ch)
+	}
 
-	w, _ := game.Size()
+	if g.GetRemainingKlingons() > 0 {
+		lines = append(lines, game.Lines("win.will_to_fight", g.GetStardate())...)
+	} else {
+		lines = append(lines, game.Lines("win.last_ship", g.GetStardate())...)
+	}
 
-	game.DrawBox(15, 2, w-15, 16)
+	timeTaken := g.GetStardate() - g.GetStartingStardate()
+	lines = append(lines, "")
+	lines = append(lines, game.Lines("win.promoted", timeTaken, timeTaken/float64(g.GetStartingKlingons()-g.GetRemainingKlingons()))...)
+	lines = append(lines, "", game.T("stats.casualties", g.GetCasualties()))
+	lines = append(lines, commanderStats(g)...)
+	lines = append(lines, "")
 
-	currentLine := 4
-	msg := fmt.Sprintf("The Enterprise was destroyed on Stardate %.1f with all hands lost.", g.GetStardate())
-	game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-	currentLine += 2
-	numberDestroyed := g.GetStartingKlingons() - g.GetRemainingKlingons()
-	switch {
-	case numberDestroyed == 0:
-		msg = "You suffered an ignominious defeat, failing to destroy even one enemy ship."
-		game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-		currentLine++
-		msg = "Your humiliation is lessened only by the fact that your ship was destroyed,"
-		game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-		currentLine++
-		msg = "lost with all of its crew - including you!"
-		game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-		currentLine++
-		msg = "Your tactics will be studied through the ages as an example of"
-		game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-		currentLine++
-		msg = "how not to conduct a war!"
-		game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-		currentLine++
-	case numberDestroyed > 0:
-		msg = fmt.Sprintf("While you managed to defeat %d enemies, the remaining %d Klingons destroyed", numberDestroyed, g.GetRemainingKlingons())
-		game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-		currentLine++
-		msg = "all the Starbases and won the war!"
-		game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-		currentLine++
-		msg = "Your defeat will go down in the annals of history!"
-		game.EmitStr(w/2-len(msg)/2, currentLine, msg)
-		currentLine++
+	displayText(lines, game.T("prompt.esc_quit"))
+	waitForEsc(ch)
+	return false
+}
+
+// playerDeadDisplay shows the defeat screen, or the debrief
+// when m is a campaign mission, along with saveErr if the
+// campaign couldn't be saved.  It returns false if the player
+// chose to quit.
+func playerDeadDisplay(g game.Game, outcome int, m *campaign.Mission, saveErr error, ch chan tcell.Event) bool {
+	var lines []string
+	if m != nil {
+		lines = append(lines, game.T("debrief.title", strings.ToUpper(m.Name)), "")
 	}
-	msg = "Press ESC to quit"
-	game.EmitStr(w/2-len(msg)/2, currentLine+2, msg)
 
-	game.ShowScreen()
+	switch outcome {
+	case game.OutOfTime:
+		lines = append(lines, game.Lines("loss.out_of_time", g.GetStardate())...)
+	case game.StarbasesLost:
+		lines = append(lines, game.LinesN("loss.starbases", g.GetRemainingStarbases(), g.GetStardate(), g.GetRemainingStarbases())...)
+	default:
+		lines = append(lines, game.T("loss.destroyed", g.GetStardate()))
+		if m == nil {
+			lines = append(lines, "")
+			lines = append(lines, playerDestroyedText(g)...)
+		}
+	}
 
+	if m != nil {
+		lines = append(lines, "")
+		lines = append(lines, missionStats(g)...)
+		if len(m.Debrief.Failure) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, m.Debrief.Failure...)
+		}
+		if saveErr != nil {
+			lines = append(lines, "", game.T("campaign.save_failed", saveErr))
+		}
+		displayText(lines, game.T("prompt.retry"))
+		return waitForContinue(ch)
+	}
+
+	if outcome != game.PlayerDestroyed {
+		lines = append(lines, "", game.TN("loss.tally", g.GetStartingKlingons(), g.GetStartingKlingons()-g.GetRemainingKlingons(), g.GetStartingKlingons()))
+	}
+	lines = append(lines, "", game.T("stats.casualties", g.GetCasualties()))
+	lines = append(lines, commanderStats(g)...)
+	displayText(lines, game.T("prompt.esc_quit"))
 	waitForEsc(ch)
+	return false
+}
+
+func playerDestroyedText(g game.Game) []string {
+	numberDestroyed := g.GetStartingKlingons() - g.GetRemainingKlingons()
+	if numberDestroyed == 0 {
+		return game.Lines("loss.ignominious")
+	}
+	return game.LinesN("loss.annals", numberDestroyed, numberDestroyed, g.GetRemainingKlingons())
 }
@@ -0,0 +1,56 @@
+package quadrant
+
+// CatchUp brings the quadrant up to date for the Enterprise
+// coming back to it.  In the time it was away the Klingons
+// have recharged their shields and moved to new sectors.
+func (q *Quadrant) CatchUp(stardate float64) {
+	if q.Departed == 0 {
+		return
+	}
+	elapsed := stardate - q.Departed
+	percent := int(elapsed * float64(q.Game.GetRules().KlingonRegenPercent))
+
+	var ships []MoveableObject
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			if o, ok := q.Objects[x][y].(MoveableObject); ok && IsKlingon(o) {
+				recharge(o, percent)
+				ships = append(ships, o)
+				q.Objects[x][y] = nil
+			}
+		}
+	}
+	// The ships have only just left their sectors, so there is
+	// always room for them
+	for _, o := range ships {
+		x, y, _ := q.RandomEmptySector()
+		o.Move(x, y)
+		q.Objects[x][y] = o
+	}
+}
+
+// recharge restores a percentage of a Klingon ship's full
+// shields
+func recharge(o Object, percent int) {
+	switch k := o.(type) {
+	case *Klingon:
+		k.Shields = rechargeShields(k.Shields, k.FullShields, percent)
+	case *Commander:
+		k.Shields = rechargeShields(k.Shields, k.FullShields, percent)
+	case *SuperCommander:
+		k.Shields = rechargeShields(k.Shields, k.FullShields, percent)
+	}
+}
+
+// rechargeShields never takes shields over full strength, nor
+// down to it if they are already stronger
+func rechargeShields(shields, full, percent int) int {
+	if shields >= full {
+		return shields
+	}
+	shields += full * percent / 100
+	if shields > full {
+		return full
+	}
+	return shields
+}
@@ -0,0 +1,69 @@
+package quadrant
+
+import (
+	"math"
+
+	"github.com/hculpan/kabtrek/game"
+)
+
+// BlackHole swallows anything that strays into it
+type BlackHole struct {
+	X int
+	Y int
+}
+
+// Location returns the location of the black hole in the quadrant
+func (b BlackHole) Location() (int, int) {
+	return b.X, b.Y
+}
+
+// TakeDamage does no damage to black hole
+func (b *BlackHole) TakeDamage(damage int) {
+	// black holes cannot be damaged
+}
+
+// GetShields returns the object's shield strength
+func (b BlackHole) GetShields() int {
+	return math.MaxInt64
+}
+
+// Name returns the display-friendly name
+func (b BlackHole) Name() string {
+	return game.T("name.blackhole")
+}
+
+// Wormhole carries ships and torpedoes to its partner,
+// which may be in another quadrant
+type Wormhole struct {
+	X int
+	Y int
+
+	// Where the partner wormhole is
+	PartnerQuadrantX int
+	PartnerQuadrantY int
+	PartnerX         int
+	PartnerY         int
+
+	// Partner is the quadrant the partner wormhole is in
+	Partner *Quadrant
+}
+
+// Location returns the location of the wormhole in the quadrant
+func (w Wormhole) Location() (int, int) {
+	return w.X, w.Y
+}
+
+// TakeDamage does no damage to wormhole
+func (w *Wormhole) TakeDamage(damage int) {
+	// wormholes cannot be damaged
+}
+
+// GetShields returns the object's shield strength
+func (w Wormhole) GetShields() int {
+	return math.MaxInt64
+}
+
+// Name returns the display-friendly name
+func (w Wormhole) Name() string {
+	return game.T("name.wormhole")
+}
@@ -0,0 +1,95 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// Full strength of the Commanders' shields
+const (
+	CommanderShields      = 2000
+	SuperCommanderShields = 4000
+)
+
+// Commander is a tougher Klingon that roams the galaxy,
+// hunting down starbases
+type Commander struct {
+	X         int
+	Y         int
+	Shields   int
+	Torpedoes int
+
+	// FullShields is what the shields recharge up to
+	FullShields int
+}
+
+// NewCommander creates a new Klingon Commander
+func NewCommander(x int, y int) *Commander {
+	return &Commander{X: x, Y: y, Shields: CommanderShields, Torpedoes: 20, FullShields: CommanderShields}
+}
+
+// Move the commander
+func (c *Commander) Move(x int, y int) {
+	c.X = x
+	c.Y = y
+}
+
+// TakeDamage does damage to commander
+func (c *Commander) TakeDamage(damage int) {
+	c.Shields -= damage
+}
+
+// Location returns the location of the Commander
+func (c Commander) Location() (int, int) {
+	return c.X, c.Y
+}
+
+// GetShields returns the object's shield strength
+func (c Commander) GetShields() int {
+	return c.Shields
+}
+
+// Name returns the display-friendly name
+func (c Commander) Name() string {
+	return game.T("name.commander")
+}
+
+// SuperCommander is the Klingon fleet's finest, and it is
+// hunting the Enterprise personally
+type SuperCommander struct {
+	X         int
+	Y         int
+	Shields   int
+	Torpedoes int
+
+	// FullShields is what the shields recharge up to
+	FullShields int
+}
+
+// NewSuperCommander creates a new Klingon Super-Commander
+func NewSuperCommander(x int, y int) *SuperCommander {
+	return &SuperCommander{X: x, Y: y, Shields: SuperCommanderShields, Torpedoes: 40, FullShields: SuperCommanderShields}
+}
+
+// Move the super-commander
+func (s *SuperCommander) Move(x int, y int) {
+	s.X = x
+	s.Y = y
+}
+
+// TakeDamage does damage to super-commander
+func (s *SuperCommander) TakeDamage(damage int) {
+	s.Shields -= damage
+}
+
+// Location returns the location of the Super-Commander
+func (s SuperCommander) Location() (int, int) {
+	return s.X, s.Y
+}
+
+// GetShields returns the object's shield strength
+func (s SuperCommander) GetShields() int {
+	return s.Shields
+}
+
+// Name returns the display-friendly name
+func (s SuperCommander) Name() string {
+	return game.T("name.super")
+}
@@ -0,0 +1,60 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// Crew morale runs from 0 to MaxMorale, starting at
+// StartingMorale
+const (
+	MaxMorale      = 100
+	StartingMorale = 80
+)
+
+// CasualtyDamage is how much damage getting past the
+// shields costs a member of the crew
+const CasualtyDamage = 100
+
+// LoseCrew records casualties, which hurt morale
+func (e *Enterprise) LoseCrew(n int) {
+	if n <= 0 {
+		return
+	}
+	if n > e.Crew {
+		n = e.Crew
+	}
+	e.Crew -= n
+	e.Casualties += n
+	e.AdjustMorale(-(n/2 + 1))
+}
+
+// AdjustMorale raises or lowers the crew's morale
+func (e *Enterprise) AdjustMorale(n int) {
+	e.Morale += n
+	if e.Morale < 0 {
+		e.Morale = 0
+	} else if e.Morale > MaxMorale {
+		e.Morale = MaxMorale
+	}
+}
+
+// Efficiency is how well the crew runs the ship, as a
+// percentage.  A full crew at starting morale manages 100,
+// and high morale can do a little better.
+func (e Enterprise) Efficiency() int {
+	result := e.Crew * (120 + e.Morale) / (2 * game.EnterpriseMaxCrew)
+	if result < 10 {
+		result = 10
+	}
+	return result
+}
+
+// replaceCrew brings the crew back up to strength from a
+// starbase, a few at a time
+func (e *Enterprise) replaceCrew(n int) {
+	e.Crew += n
+	if e.Crew > game.EnterpriseMaxCrew {
+		e.Crew = game.EnterpriseMaxCrew
+	}
+	if e.Morale < StartingMorale {
+		e.AdjustMorale(1)
+	}
+}
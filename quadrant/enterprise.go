@@ -2,6 +2,14 @@ package quadrant
 
 import "github.com/hculpan/kabtrek/game"
 
+// States of the landing party
+const (
+	PartyAboard = iota
+	PartyLanding
+	PartyDown
+	PartyReturning
+)
+
 // Enterprise : Information relating to the player ship
 type Enterprise struct {
 	X         int
@@ -9,8 +17,51 @@ type Enterprise struct {
 	QuadrantX int
 	QuadrantY int
 	Energy    int
-	Shields   int
 	Torpedoes int
+	Probes    int
+
+	// ShieldArcs holds the strength of each arc, and Heading
+	// is the direction the ship last moved in
+	ShieldArcs [4]int
+	Heading    int
+
+	// Hits counts how many times the ship has been damaged
+	Hits int
+
+	// Orbiting is set while in standard orbit around a planet
+	Orbiting bool
+
+	// The landing party, and the dilithium it has mined
+	// but not yet brought aboard
+	Party          int
+	PartyTurns     int
+	PartyByShuttle bool
+	PartyCrystals  int
+
+	// Crystals are the dilithium crystals aboard, and
+	// CrystalBoosts counts how often they have been used
+	Crystals      int
+	CrystalBoosts int
+
+	// Crew is the ship's complement, less the Casualties
+	// so far.  Morale is lowered by losses and raised by
+	// victories.
+	Crew       int
+	Casualties int
+	Morale     int
+
+	// Power shared between the engines, shields and weapons,
+	// PowerUnits in all
+	EnginePower int
+	ShieldPower int
+	WeaponPower int
+
+	// LifeSupport is how many turns the reserves will last
+	// with the energy gone
+	LifeSupport int
+
+	// Destroyed is set when the ship is lost outright
+	Destroyed bool
 }
 
 // NewEnterprise creates a new Enterprise
@@ -19,30 +70,68 @@ func NewEnterprise(xloc int, yloc int) *Enterprise {
 		X:         xloc,
 		Y:         yloc,
 		Energy:    game.EnterpriseMaxEnergy,
-		Shields:   0,
 		Torpedoes: game.EnterpriseMaxTorpedoes,
+		Probes:    game.EnterpriseMaxProbes,
+		Heading:   Dir8,
+		Crew:      game.EnterpriseMaxCrew,
+		Morale:    StartingMorale,
+
+		EnginePower: PowerUnits / 3,
+		ShieldPower: PowerUnits / 3,
+		WeaponPower: PowerUnits / 3,
+		LifeSupport: LifeSupportReserve,
 	}
 }
 
 // Move the enterprise
 func (e *Enterprise) Move(x int, y int) {
 	if e.X != x || e.Y != y {
-		e.Energy -= EnergyToMove + ((e.Shields / 1000) * EnergyToMove)
+		e.Energy -= e.engineCost(EnergyToMove + ((e.Shields() / 1000) * EnergyToMove))
+		e.Orbiting = false
 	}
 
 	e.X = x
 	e.Y = y
 }
 
-// TakeDamage reduces damage to Enterprise
+// TakeDamage reduces damage to Enterprise, taking
+// it on the fore shields
 func (e *Enterprise) TakeDamage(damage int) {
-	if damage <= e.Shields {
-		e.Shields -= damage
-	} else {
-		damage -= e.Shields
-		e.Shields = 0
-		e.Energy -= damage * 2
+	e.TakeHit(ArcFore, damage)
+}
+
+// TakeHit does damage to the Enterprise through one of
+// the shield arcs
+func (e *Enterprise) TakeHit(arc int, damage int) {
+	e.Hits++
+
+	// A short-handed crew cannot keep the shields tuned, and
+	// without power they cannot hold, so either way they
+	// drain faster
+	factor := e.Efficiency() * powerFactor(e.ShieldPower) / 100
+	if factor > 0 && damage*100/factor <= e.ShieldArcs[arc] {
+		e.ShieldArcs[arc] -= damage * 100 / factor
+		return
+	}
+
+	damage -= e.ShieldArcs[arc] * factor / 100
+	e.ShieldArcs[arc] = 0
+	if e.Energy <= 0 {
+		// The hit comes out of the life support reserves, and
+		// once they are gone nothing keeps the ship together
+		e.LifeSupport -= (damage + LifeSupportHitDamage - 1) / LifeSupportHitDamage
+		e.LoseCrew(damage / CasualtyDamage)
+		if e.LifeSupport <= 0 {
+			e.LifeSupport = 0
+			e.Destroyed = true
+		}
+		return
+	}
+	e.Energy -= damage * 2
+	if e.Energy < 0 {
+		e.Energy = 0
 	}
+	e.LoseCrew(damage / CasualtyDamage)
 }
 
 // Location returns the location of the Enterprise
@@ -55,16 +144,19 @@ func (e *Enterprise) IsPlayer() bool {
 	return true
 }
 
-// GetShields returns the object's shield strength
+// GetShields returns the object's shield strength, or with
+// the shields down, what is left to keep the ship going
 func (e Enterprise) GetShields() int {
-	if e.Shields > 0 {
-		return e.Shields
+	if e.Shields() > 0 {
+		return e.Shields()
+	} else if e.Destroyed {
+		return 0
 	} else {
-		return e.Energy
+		return e.Energy + e.LifeSupport
 	}
 }
 
 // Name returns the display-friendly name
 func (e Enterprise) Name() string {
-	return "Enterprise"
+	return game.T("name.enterprise")
 }
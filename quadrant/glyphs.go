@@ -0,0 +1,60 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// asciiGlyphs are the classic three character symbols
+// for each kind of object on the sector map
+var asciiGlyphs = map[string]string{
+	"enterprise": "-E-",
+	"klingon":    "-K-",
+	"commander":  "-C-",
+	"super":      "-S-",
+	"romulan":    "-R-",
+	"tholian":    "-T-",
+	"web":        ":::",
+	"starbase":   ">B<",
+	"star":       " * ",
+	"blackhole":  " # ",
+	"wormhole":   "<W>",
+	"torpedo":    " @ ",
+	"plasma":     " o ",
+}
+
+// unicodeGlyphs replace the ASCII symbols when the theme
+// asks for Unicode
+var unicodeGlyphs = map[string]string{
+	"klingon":   " ♦ ",
+	"commander": " ◆ ",
+	"super":     " ❖ ",
+	"romulan":   " ◊ ",
+	"tholian":   " △ ",
+	"web":       "░░░",
+	"starbase":  "[⌂]",
+	"star":      " ✶ ",
+	"blackhole": " ● ",
+	"wormhole":  " ◎ ",
+	"torpedo":   " • ",
+	"plasma":    " ○ ",
+}
+
+// headingArrows point the Unicode Enterprise along its heading
+var headingArrows = [10]string{"", "↙", "↓", "↘", "←", "", "→", "↖", "↑", "↗"}
+
+// glyph returns the symbol for an element of the sector map
+func glyph(element string) string {
+	if game.CurrentTheme().Unicode {
+		if g, ok := unicodeGlyphs[element]; ok {
+			return g
+		}
+	}
+	return asciiGlyphs[element]
+}
+
+// enterpriseGlyph returns the Enterprise's symbol, which in
+// Unicode shows the way the ship is heading
+func (q *Quadrant) enterpriseGlyph() string {
+	if game.CurrentTheme().Unicode && headingArrows[q.Player.Heading] != "" {
+		return "<" + headingArrows[q.Player.Heading] + ">"
+	}
+	return asciiGlyphs["enterprise"]
+}
@@ -1,16 +1,25 @@
 package quadrant
 
+import "github.com/hculpan/kabtrek/game"
+
+// KlingonShields is the strength of a Klingon's shields when
+// fully charged
+const KlingonShields = 1000
+
 // Klingon for the Enterprise to blow up
 type Klingon struct {
 	X         int
 	Y         int
 	Shields   int
 	Torpedoes int
+
+	// FullShields is what the shields recharge up to
+	FullShields int
 }
 
 // NewKlingon creates a new Klingon
 func NewKlingon(x int, y int) *Klingon {
-	return &Klingon{X: x, Y: y, Shields: 1000, Torpedoes: 10}
+	return &Klingon{X: x, Y: y, Shields: KlingonShields, Torpedoes: 10, FullShields: KlingonShields}
 }
 
 // Move the klingon
@@ -36,5 +45,5 @@ func (k Klingon) GetShields() int {
 
 // Name returns the display-friendly name
 func (k Klingon) Name() string {
-	return "Klingon"
+	return game.T("name.klingon")
 }
@@ -0,0 +1,186 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// Planet landing constants
+const (
+	TransporterEnergy = 50
+	ShuttleTurns      = 3
+)
+
+// orbitedPlanet returns the planet next to the Enterprise
+func (q *Quadrant) orbitedPlanet() *Planet {
+	for x := q.Player.X - 1; x <= q.Player.X+1; x++ {
+		for y := q.Player.Y - 1; y <= q.Player.Y+1; y++ {
+			if !q.Size.Contains(x, y) {
+				continue
+			}
+			if p, ok := q.Objects[x][y].(*Planet); ok {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// Orbit puts the Enterprise into standard orbit around a
+// neighbouring planet, returning false if it can't
+func (q *Quadrant) Orbit() bool {
+	p := q.orbitedPlanet()
+	switch {
+	case q.Player.Orbiting:
+		q.AddMessage(game.T("msg.already_orbiting"))
+	case p == nil:
+		q.AddMessage(game.T("msg.no_planet"))
+	default:
+		q.Player.Orbiting = true
+		q.AddMessage(game.T("msg.orbit", p.Name()))
+		return true
+	}
+	return false
+}
+
+// LeaveOrbit checks the Enterprise is free to leave orbit,
+// which it cannot do with the landing party away
+func (q *Quadrant) LeaveOrbit() bool {
+	if q.Player.Orbiting && q.Player.Party != PartyAboard {
+		q.AddAlert(game.T("alert.party_away"))
+		return false
+	}
+	q.Player.Orbiting = false
+	return true
+}
+
+func (q *Quadrant) canSendParty() bool {
+	switch {
+	case !q.Player.Orbiting:
+		q.AddMessage(game.T("msg.not_orbiting"))
+	case q.Player.Party != PartyAboard:
+		q.AddMessage(game.T("msg.party_already_away"))
+	default:
+		return true
+	}
+	return false
+}
+
+// BeamDown sends the landing party to the planet by
+// transporter, returning false if it can't
+func (q *Quadrant) BeamDown() bool {
+	switch {
+	case !q.canSendParty():
+	case q.Player.Shields() > 0:
+		q.AddMessage(game.T("msg.transporter_shields"))
+	case q.Player.Energy < TransporterEnergy:
+		q.AddMessage(game.T("msg.transporter_energy"))
+	default:
+		q.Player.Energy -= TransporterEnergy
+		q.Player.Party = PartyDown
+		q.Player.PartyByShuttle = false
+		q.AddMessage(game.T("msg.beamed_down"))
+		return true
+	}
+	return false
+}
+
+// TakeShuttle sends the landing party down by shuttlecraft,
+// which is slower but works with the shields up.  It returns
+// false if the party can't go.
+func (q *Quadrant) TakeShuttle() bool {
+	if !q.canSendParty() {
+		return false
+	}
+	q.Player.Party = PartyLanding
+	q.Player.PartyTurns = ShuttleTurns
+	q.Player.PartyByShuttle = true
+	q.AddMessage(game.T("msg.shuttle_launched"))
+	return true
+}
+
+// RecallParty brings the landing party back the way it went,
+// returning false if it can't
+func (q *Quadrant) RecallParty() bool {
+	switch {
+	case q.Player.Party != PartyDown:
+		q.AddMessage(game.T("msg.party_not_down"))
+	case q.Player.PartyByShuttle:
+		q.Player.Party = PartyReturning
+		q.Player.PartyTurns = ShuttleTurns
+		q.AddMessage(game.T("msg.shuttle_returning"))
+		return true
+	case q.Player.Shields() > 0:
+		q.AddMessage(game.T("msg.transporter_shields"))
+	case q.Player.Energy < TransporterEnergy:
+		q.AddMessage(game.T("msg.transporter_energy"))
+	default:
+		q.Player.Energy -= TransporterEnergy
+		q.partyAboard()
+		return true
+	}
+	return false
+}
+
+func (q *Quadrant) partyAboard() {
+	q.Player.Party = PartyAboard
+	q.Player.Crystals += q.Player.PartyCrystals
+	q.AddMessage(game.TN("msg.party_aboard", q.Player.PartyCrystals, q.Player.PartyCrystals))
+	q.Player.PartyCrystals = 0
+}
+
+// updateLandingParty moves the shuttle along, and has the
+// party mine while it is on the planet
+func (q *Quadrant) updateLandingParty() {
+	switch q.Player.Party {
+	case PartyLanding:
+		q.Player.PartyTurns--
+		if q.Player.PartyTurns <= 0 {
+			q.Player.Party = PartyDown
+			q.AddMessage(game.T("msg.shuttle_landed"))
+		}
+	case PartyReturning:
+		q.Player.PartyTurns--
+		if q.Player.PartyTurns <= 0 {
+			q.partyAboard()
+		}
+	case PartyDown:
+		if p := q.orbitedPlanet(); p != nil && p.Dilithium > 0 {
+			p.Dilithium--
+			q.Player.PartyCrystals++
+			if p.Dilithium == 0 {
+				q.AddMessage(game.T("msg.dilithium_exhausted"))
+			}
+		}
+	}
+}
+
+// DilithiumBoost burns the crystals aboard for an emergency
+// energy boost.  It does not always go to plan.  It returns
+// false if there are no crystals to burn.
+func (q *Quadrant) DilithiumBoost() bool {
+	if q.Player.Crystals == 0 {
+		q.AddMessage(game.T("msg.no_crystals"))
+		return false
+	}
+
+	rules := q.Game.GetRules()
+	crystals := q.Player.Crystals
+	risk := rules.CrystalRiskPercent * (q.Player.CrystalBoosts + 1)
+	q.Player.Crystals = 0
+	q.Player.CrystalBoosts++
+
+	if q.Game.GetRandom().CheckPercent(risk) {
+		damage := crystals * rules.CrystalEnergy / 2
+		q.Player.Energy -= damage
+		q.Player.Hits++
+		q.Player.LoseCrew(damage / CasualtyDamage)
+		q.AddAlert(game.T("alert.dilithium_explosion", damage))
+		return true
+	}
+
+	boost := crystals * rules.CrystalEnergy
+	q.Player.Energy += boost
+	if q.Player.Energy > game.EnterpriseMaxEnergy {
+		q.Player.Energy = game.EnterpriseMaxEnergy
+	}
+	q.AddMessage(game.T("msg.dilithium_boost", boost))
+	return true
+}
@@ -0,0 +1,65 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// SectorAt returns the sector of the map drawn at a
+// screen position, if there is one
+func (q *Quadrant) SectorAt(x int, y int) (int, int, bool) {
+	r := game.CurrentLayout().Map
+	sx, sy := x-r.X-3, y-r.Y-2
+	if sx < 0 || sy < 0 || sx >= q.Size.Width*4 || sy >= q.Size.Height {
+		return 0, 0, false
+	}
+	return sx / 4, sy, true
+}
+
+// ClickSector acts on a click on a sector of the map,
+// returning the direction the Enterprise should move to
+// head for it, or Dir5 if it should stay where it is.
+// When a torpedo is being aimed, the click fires it
+// towards the sector instead.
+func (q *Quadrant) ClickSector(sx int, sy int) int {
+	direction := DirectionTo(q.Player.X, q.Player.Y, sx, sy)
+	switch q.UIState {
+	case Weapons, WeaponsTorpedoes:
+		if direction != Dir5 {
+			if q.FireTorpedo(direction) {
+				q.Game.Spend(game.ActionTorpedo)
+			}
+			q.UpdateState(Normal)
+		}
+		return Dir5
+	case Normal:
+		return direction
+	}
+	return Dir5
+}
+
+// Hover notes the sector the mouse is over, so the map can
+// describe what is there; it returns false if nothing changed
+func (q *Quadrant) Hover(sx int, sy int, over bool) bool {
+	if q.hovering == over && q.hoverX == sx && q.hoverY == sy {
+		return false
+	}
+	q.hovering, q.hoverX, q.hoverY = over, sx, sy
+	return true
+}
+
+// DisplayTooltip describes the object under the mouse
+func (q *Quadrant) DisplayTooltip() {
+	if !q.hovering {
+		return
+	}
+	o := q.Objects[q.hoverX][q.hoverY]
+	if r, ok := o.(*Romulan); o == nil || ok && r.Cloaked {
+		return
+	}
+
+	text := game.T("tooltip.object", o.Name(), q.hoverX, q.hoverY)
+	switch o.(type) {
+	case *Star, *Planet, *BlackHole, *Wormhole, *Web:
+	default:
+		text += game.T("tooltip.shields", o.GetShields())
+	}
+	game.CurrentLayout().Tooltip.Emit(0, 0, text)
+}
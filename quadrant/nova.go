@@ -0,0 +1,50 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// Nova sets off the star at the given sector, damaging
+// everything next to it.  Neighbouring stars may go too.
+func (q *Quadrant) Nova(sx int, sy int) {
+	rules := q.Game.GetRules()
+	q.AddAlert(game.T("alert.nova", sx, sy))
+	q.Objects[sx][sy] = nil
+	q.NumberOfStars--
+
+	for x := sx - 1; x <= sx+1; x++ {
+		for y := sy - 1; y <= sy+1; y++ {
+			if !q.Size.Contains(x, y) {
+				continue
+			}
+			switch q.Objects[x][y].(type) {
+			case *Star:
+				if q.Game.GetRandom().CheckPercent(rules.NovaChainPercent) {
+					q.Nova(x, y)
+				}
+			case MoveableObject, *Starbase:
+				q.damageObjectAt(x, y, rules.NovaDamage, game.T("weapon.nova"), DirectionTo(sx, sy, x, y))
+			}
+		}
+	}
+}
+
+// GoSupernova destroys everything in the quadrant, and
+// leaves it uninhabitable
+func (q *Quadrant) GoSupernova() {
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			q.torpedoes[x][y] = nil
+			if q.Objects[x][y] == nil {
+				continue
+			}
+			if p, ok := q.Objects[x][y].(*Enterprise); ok {
+				p.Destroyed = true
+				q.Objects[x][y] = nil
+			} else {
+				q.destroyObjectAt(x, y)
+			}
+		}
+	}
+	q.NumberOfStars = 0
+	q.NumberOfPlanets = 0
+	q.Supernova = true
+}
@@ -0,0 +1,51 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// phaserTargets returns the enemy ships the phasers can lock on to
+func (q *Quadrant) phaserTargets() []Object {
+	var result []Object
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			switch o := q.Objects[x][y].(type) {
+			case *Klingon, *Commander, *SuperCommander, *Tholian:
+				result = append(result, o)
+			case *Romulan:
+				if !o.Cloaked {
+					result = append(result, o)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// FirePhasers spends energy on a phaser burst, which is shared
+// between the enemy ships in the quadrant and weakens with
+// distance
+func (q *Quadrant) FirePhasers(energy int) bool {
+	if q.Player.WeaponPower == 0 {
+		q.AddAlert(game.T("alert.no_weapon_power"))
+		return false
+	}
+	if energy <= 0 || energy > q.Player.Energy {
+		q.AddMessage(game.T("msg.phaser_energy"))
+		return false
+	}
+	targets := q.phaserTargets()
+	if len(targets) == 0 {
+		q.AddMessage(game.T("msg.no_targets"))
+		return false
+	}
+
+	q.Player.Energy -= energy
+	q.AddCombatMessage(game.T("combat.phasers_fired", energy))
+	share := energy * q.Player.Efficiency() / 100 * powerFactor(q.Player.WeaponPower) / 100 / len(targets)
+	for _, t := range targets {
+		x, y := t.Location()
+		distance := game.Distance(q.Player.X, q.Player.Y, x, y)
+		damage := int(float64(share*(200+q.Game.GetRandom().GetPercent())/100) / distance)
+		q.damageObjectAt(x, y, damage, game.T("weapon.phaser"), DirectionTo(q.Player.X, q.Player.Y, x, y))
+	}
+	return true
+}
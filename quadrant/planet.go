@@ -0,0 +1,48 @@
+package quadrant
+
+import (
+	"math"
+
+	"github.com/hculpan/kabtrek/game"
+)
+
+// Planet classes
+const (
+	ClassM = "M"
+	ClassN = "N"
+	ClassO = "O"
+)
+
+// Planet is a world the Enterprise can orbit and send a
+// landing party down to
+type Planet struct {
+	X         int
+	Y         int
+	Class     string
+	Dilithium int
+}
+
+// NewPlanet creates a new planet of the given class
+func NewPlanet(x int, y int, class string, dilithium int) *Planet {
+	return &Planet{X: x, Y: y, Class: class, Dilithium: dilithium}
+}
+
+// Location returns the location of the planet in the quadrant
+func (p Planet) Location() (int, int) {
+	return p.X, p.Y
+}
+
+// TakeDamage does no damage to planet
+func (p *Planet) TakeDamage(damage int) {
+	// planets cannot be damaged
+}
+
+// GetShields returns the object's shield strength
+func (p Planet) GetShields() int {
+	return math.MaxInt64
+}
+
+// Name returns the display-friendly name
+func (p Planet) Name() string {
+	return game.T("name.planet", p.Class)
+}
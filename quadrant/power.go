@@ -0,0 +1,94 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// Each turn the Enterprise draws energy for life support and
+// its idle systems, and ShieldUpkeep strength of shields
+// costs another unit to hold
+const (
+	LifeSupportDraw = 2
+	IdleDraw        = 1
+	ShieldUpkeep    = 500
+)
+
+// LifeSupportReserve is how many turns the reserves keep
+// the crew alive once the energy runs out
+const LifeSupportReserve = 50
+
+// LifeSupportHitDamage is how much damage taken with no
+// energy left costs a turn of the reserves
+const LifeSupportHitDamage = 50
+
+// PowerUnits is the power shared between the engines,
+// shields and weapons.  An even share is normal performance.
+const PowerUnits = 9
+
+// powerFactor turns units of power into a percentage of
+// normal performance
+func powerFactor(units int) int {
+	return units * 300 / PowerUnits
+}
+
+// AllocatePower shares the power between the engines, shields
+// and weapons, given as three digits
+func (q *Quadrant) AllocatePower(digits string) bool {
+	if len(digits) != 3 {
+		q.AddMessage(game.T("msg.power_digits"))
+		return false
+	}
+	var units [3]int
+	total := 0
+	for i, c := range digits {
+		units[i] = int(c - '0')
+		total += units[i]
+	}
+	if total != PowerUnits {
+		q.AddMessage(game.T("msg.power_total", PowerUnits))
+		return false
+	}
+
+	q.Player.EnginePower, q.Player.ShieldPower, q.Player.WeaponPower = units[0], units[1], units[2]
+	q.AddMessage(game.T("msg.power_set", units[0], units[1], units[2]))
+	return true
+}
+
+// EnginesReady checks the Enterprise has the power to move
+func (q *Quadrant) EnginesReady() bool {
+	if q.Player.EnginePower == 0 {
+		q.AddAlert(game.T("alert.no_engine_power"))
+		return false
+	}
+	if q.Player.Energy <= 0 {
+		q.AddAlert(game.T("alert.no_engine_energy"))
+		return false
+	}
+	return true
+}
+
+// engineCost is what the engines take for work that would
+// normally cost the energy given
+func (e Enterprise) engineCost(energy int) int {
+	if f := powerFactor(e.EnginePower); f > 0 {
+		return energy * 100 / f
+	}
+	return energy
+}
+
+// drawPower takes the energy for a turn.  Once the energy
+// is gone, the shields fail and life support runs on its
+// reserves.
+func (q *Quadrant) drawPower() {
+	p := q.Player
+	p.Energy -= LifeSupportDraw + IdleDraw + p.Shields()/ShieldUpkeep
+	if p.Energy > 0 {
+		p.LifeSupport = LifeSupportReserve
+		return
+	}
+
+	p.Energy = 0
+	p.ShieldArcs = [4]int{}
+	if p.LifeSupport == LifeSupportReserve {
+		q.AddAlert(game.T("alert.life_support"))
+	}
+	p.LifeSupport--
+}
@@ -0,0 +1,25 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// LaunchProbe sends a deep space probe off across the galaxy
+// in the given direction.  An armed probe detonates when it
+// has flown target quadrants; an unarmed one, with a target
+// of 0, flies on until it leaves the galaxy.
+func (q *Quadrant) LaunchProbe(direction int, target int) bool {
+	if q.Player.Probes <= 0 {
+		q.AddAlert(game.T("alert.no_probes"))
+		return false
+	}
+	if direction < 1 || direction > 9 || direction == Dir5 || target < 0 {
+		return false
+	}
+	q.Player.Probes--
+	q.Game.LaunchProbe(q.X, q.Y, direction, target)
+	if target > 0 {
+		q.AddCombatMessage(game.TN("combat.probe_armed", target, direction, target))
+	} else {
+		q.AddMessage(game.T("msg.probe_launched", direction))
+	}
+	return true
+}
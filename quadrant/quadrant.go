@@ -3,6 +3,7 @@ package quadrant
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/hculpan/kabtrek/game"
@@ -29,58 +30,83 @@ const (
 	NavigationY
 	Weapons
 	Shields
+	ShieldsMenu
+	ShieldArc
 	Sensors
 	Computer
+	Planets
 
 	WeaponsPhasers
 	WeaponsTorpedoes
+	Power
+	ProbeCourse
+	ProbeRange
 )
 
 // Game constants
 const (
-	EnergyToMove  = 10
-	TorpedoDamage = 500
+	EnergyToMove = 10
 )
 
-// Message type for ui messages
-type Message struct {
-	Text     string
-	Stardate float64
-}
-
 // Quadrant : All the information related to a single Quadrant
 type Quadrant struct {
 	X                         int
 	Y                         int
-	Objects                   [10][10]Object
+	Size                      game.Dimensions
+	Objects                   [][]Object
 	Player                    *Enterprise
 	StartingNumberOfKlingons  int
 	NumberOfKlingons          int
 	StartingNumberOfStarbases int
 	NumberOfStarbases         int
 	NumberOfStars             int
+	NumberOfRomulans          int
+	NumberOfPlanets           int
 	Scanned                   bool
+	LastScan                  ScanRecord
+	Supernova                 bool
 	Game                      game.Game
 
+	// Departed is the stardate the Enterprise last left
+	// the quadrant, or 0 if it has never been here
+	Departed float64
+
 	UIState       int
 	AwaitingInput bool
 	CurrentInput  string
 
-	Messages []Message
-
 	// Private variables
 	blinkRed     int
-	torpedoes    [10][10]*Torpedo
+	torpedoes    [][]*Torpedo
 	destinationX int
+	shieldArc    int
+	probeArmed   bool
+	probeCourse  int
+	hovering     bool
+	hoverX       int
+	hoverY       int
+}
+
+// ScanRecord is what the sensors last saw in a quadrant,
+// and when
+type ScanRecord struct {
+	Klingons  int
+	Romulans  int
+	Starbases int
+	Planets   int
+	Stars     int
+	Stardate  float64
 }
 
 // NewQuadrant creates a new quadrant, populated with items
 func NewQuadrant(parentGame game.Game, x int, y int, numKlingons int, numStars int, numBases int) *Quadrant {
+	size := parentGame.GetRules().Quadrant
 	result := &Quadrant{
 		Game:                      parentGame,
 		X:                         x,
 		Y:                         y,
-		Objects:                   [10][10]Object{},
+		Size:                      size,
+		Objects:                   make([][]Object, size.Width),
 		Player:                    nil,
 		NumberOfKlingons:          numKlingons,
 		StartingNumberOfKlingons:  numKlingons,
@@ -92,7 +118,11 @@ func NewQuadrant(parentGame game.Game, x int, y int, numKlingons int, numStars i
 		Scanned:                   false,
 		CurrentInput:              "",
 		blinkRed:                  0,
-		torpedoes:                 [10][10]*Torpedo{},
+		torpedoes:                 make([][]*Torpedo, size.Width),
+	}
+	for x := range result.Objects {
+		result.Objects[x] = make([]Object, size.Height)
+		result.torpedoes[x] = make([]*Torpedo, size.Height)
 	}
 
 	result.blinkRed = 0
@@ -100,11 +130,13 @@ func NewQuadrant(parentGame game.Game, x int, y int, numKlingons int, numStars i
 	result.AwaitingInput = false
 	result.CurrentInput = ""
 
+	rnd := parentGame.GetRandom()
+
 	result.NumberOfKlingons = numKlingons
 	klingonsToPlace := numKlingons
 	for klingonsToPlace > 0 {
-		xloc := game.RandomInt(10)
-		yloc := game.RandomInt(10)
+		xloc := rnd.RandomInt(size.Width)
+		yloc := rnd.RandomInt(size.Height)
 		if result.Objects[xloc][yloc] == nil {
 			result.Objects[xloc][yloc] = NewKlingon(xloc, yloc)
 			klingonsToPlace--
@@ -113,8 +145,8 @@ func NewQuadrant(parentGame game.Game, x int, y int, numKlingons int, numStars i
 
 	starsToPlace := numStars
 	for starsToPlace > 0 {
-		xloc := game.RandomInt(10)
-		yloc := game.RandomInt(10)
+		xloc := rnd.RandomInt(size.Width)
+		yloc := rnd.RandomInt(size.Height)
 		if result.Objects[xloc][yloc] == nil {
 			result.Objects[xloc][yloc] = &Star{X: xloc, Y: yloc}
 			starsToPlace--
@@ -123,8 +155,8 @@ func NewQuadrant(parentGame game.Game, x int, y int, numKlingons int, numStars i
 
 	basesToPlace := numBases
 	for basesToPlace > 0 {
-		xloc := game.RandomInt(10)
-		yloc := game.RandomInt(10)
+		xloc := rnd.RandomInt(size.Width)
+		yloc := rnd.RandomInt(size.Height)
 		if result.Objects[xloc][yloc] == nil {
 			result.Objects[xloc][yloc] = NewStarbase(xloc, yloc)
 			basesToPlace--
@@ -134,8 +166,147 @@ func NewQuadrant(parentGame game.Game, x int, y int, numKlingons int, numStars i
 	return result
 }
 
+// AddObject places an object in the quadrant at its own
+// location, keeping the quadrant's counts up to date
+func (q *Quadrant) AddObject(o Object) {
+	x, y := o.Location()
+	q.Objects[x][y] = o
+	switch o.(type) {
+	case *Klingon:
+		q.NumberOfKlingons++
+		q.StartingNumberOfKlingons++
+	case *Starbase:
+		q.NumberOfStarbases++
+		q.StartingNumberOfStarbases++
+	case *Star:
+		q.NumberOfStars++
+	case *Romulan:
+		q.NumberOfRomulans++
+	case *Planet:
+		q.NumberOfPlanets++
+	case *Commander, *SuperCommander:
+		q.NumberOfKlingons++
+		q.StartingNumberOfKlingons++
+	}
+}
+
+// EnterObject brings a ship in from another quadrant,
+// placing it in a random empty sector, if there is one
+func (q *Quadrant) EnterObject(m MoveableObject) bool {
+	x, y, ok := q.RandomEmptySector()
+	if !ok {
+		return false
+	}
+	q.enterObjectAt(m, x, y)
+	return true
+}
+
+func (q *Quadrant) enterObjectAt(m MoveableObject, x int, y int) {
+	m.Move(x, y)
+	q.Objects[x][y] = m
+	switch m.(type) {
+	case *Klingon, *Commander, *SuperCommander:
+		q.NumberOfKlingons++
+	case *Romulan:
+		q.NumberOfRomulans++
+	}
+}
+
+// RemoveObject takes a ship out of the quadrant, as it
+// leaves for another
+func (q *Quadrant) RemoveObject(o Object) {
+	x, y := o.Location()
+	if q.Objects[x][y] != o {
+		return
+	}
+	q.Objects[x][y] = nil
+	switch o.(type) {
+	case *Klingon, *Commander, *SuperCommander:
+		q.NumberOfKlingons--
+	case *Romulan:
+		q.NumberOfRomulans--
+	}
+}
+
+// EmptySectors counts the sectors of the quadrant with nothing
+// in them
+func (q *Quadrant) EmptySectors() int {
+	result := 0
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			if q.Objects[x][y] == nil {
+				result++
+			}
+		}
+	}
+	return result
+}
+
+// RandomEmptySector picks an empty sector of the quadrant,
+// returning false if there are none left
+func (q *Quadrant) RandomEmptySector() (int, int, bool) {
+	if q.EmptySectors() == 0 {
+		return 0, 0, false
+	}
+	rnd := q.Game.GetRandom()
+	for {
+		x := rnd.RandomInt(q.Size.Width)
+		y := rnd.RandomInt(q.Size.Height)
+		if q.Objects[x][y] == nil {
+			return x, y, true
+		}
+	}
+}
+
+// Scan records what the sensors can see in the quadrant
+func (q *Quadrant) Scan(stardate float64) {
+	q.Scanned = true
+	q.LastScan = ScanRecord{
+		Klingons:  q.NumberOfKlingons,
+		Romulans:  q.VisibleRomulans(),
+		Starbases: q.NumberOfStarbases,
+		Planets:   q.NumberOfPlanets,
+		Stars:     q.NumberOfStars,
+		Stardate:  stardate,
+	}
+}
+
+// VisibleRomulans counts the Romulans that are not cloaked
+func (q *Quadrant) VisibleRomulans() int {
+	result := 0
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			if r, ok := q.Objects[x][y].(*Romulan); ok && !r.Cloaked {
+				result++
+			}
+		}
+	}
+	return result
+}
+
+// SiegeStarbase has a ship off the Enterprise's screens
+// attack a starbase in the quadrant.  It returns whether
+// there was a starbase to attack, and if it was destroyed.
+func (q *Quadrant) SiegeStarbase(damage int) (bool, bool) {
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			if b, ok := q.Objects[x][y].(*Starbase); ok {
+				b.TakeDamage(damage)
+				if b.GetShields() > 0 {
+					return true, false
+				}
+				q.Objects[x][y] = nil
+				q.NumberOfStarbases--
+				q.Game.StarbaseDestroyed()
+				return true, true
+			}
+		}
+	}
+	return false, false
+}
+
 func (q *Quadrant) isBaseAt(x int, y int) bool {
-	if x < 0 || x > 9 || y < 0 || y > 9 || q.Objects[x][y] == nil {
+	if !q.Size.Contains(x, y) || q.Objects[x][y] == nil {
 		return false
 	} else {
 		switch q.Objects[x][y].(type) {
@@ -149,7 +320,7 @@ func (q *Quadrant) isBaseAt(x int, y int) bool {
 
 func (q *Quadrant) playerDockedAtBase() bool {
 	x, y := q.Player.Location()
-	if q.Player.Shields == 0 &&
+	if q.Player.Shields() == 0 &&
 		(q.isBaseAt(x, y-1) ||
 			q.isBaseAt(x-1, y) ||
 			q.isBaseAt(x+1, y) ||
@@ -162,10 +333,13 @@ func (q *Quadrant) playerDockedAtBase() bool {
 // UpdateState changes the current state of the UI
 func (q *Quadrant) UpdateState(newState int) {
 	q.UIState = newState
-	if newState != Normal && newState != Weapons && newState != NavigationX && newState != NavigationY {
-		q.AwaitingInput = true
-	} else {
+	switch newState {
+	case Normal, Weapons, Planets, ShieldsMenu:
 		q.AwaitingInput = false
+	case NavigationX, NavigationY:
+		q.AwaitingInput = q.typedNavigation()
+	default:
+		q.AwaitingInput = true
 	}
 	q.CurrentInput = ""
 	q.Game.Draw()
@@ -173,35 +347,88 @@ func (q *Quadrant) UpdateState(newState int) {
 
 // UpdateTorpedoes moves the torpedo along it's path
 func (q *Quadrant) UpdateTorpedoes() {
-	for x := 0; x < 10; x++ {
-		for y := 0; y < 10; y++ {
+	// Find them all first, so each only moves one sector
+	var inFlight []*Torpedo
+	for x := range q.torpedoes {
+		for y := range q.torpedoes[x] {
+			if q.torpedoes[x][y] != nil {
+				inFlight = append(inFlight, q.torpedoes[x][y])
+			}
+		}
+	}
+
+	for _, t := range inFlight {
+		if x, y := t.Location(); q.torpedoes[x][y] == t {
 			q.updateTorpedoAt(x, y)
 		}
 	}
 }
 
+// TorpedoesInFlight returns the number of torpedoes
+// currently moving through the quadrant
+func (q *Quadrant) TorpedoesInFlight() int {
+	result := 0
+	for x := range q.torpedoes {
+		for y := range q.torpedoes[x] {
+			if q.torpedoes[x][y] != nil {
+				result++
+			}
+		}
+	}
+	return result
+}
+
 // IsPlayerDead checks if game is over
 func (q *Quadrant) IsPlayerDead() bool {
-	return q.Player.Energy <= 0
+	return q.Player.Destroyed || (q.Player.Energy <= 0 && q.Player.LifeSupport <= 0)
 }
 
-func (q *Quadrant) damageObjectAt(x int, y int, damage int, deiptor string) {
+// damageObjectAt damages whatever is at the sector, hit by
+// something travelling in the given direction
+func (q *Quadrant) damageObjectAt(x int, y int, damage int, deiptor string, direction int) {
 	if q.Objects[x][y] == nil {
 		return
 	}
 
-	q.Objects[x][y].TakeDamage(TorpedoDamage)
-	q.AddMessage(fmt.Sprintf("%s at %d, %d took %d damage from a %s", q.Objects[x][y].Name(), x, y, damage, deiptor))
+	if p, ok := q.Objects[x][y].(*Enterprise); ok {
+		arc := p.ArcFacing(direction)
+		p.TakeHit(arc, damage)
+		q.AddCombatMessage(game.T("combat.enterprise_hit", damage, game.T("arc."+ArcNames[arc]), deiptor))
+	} else {
+		q.Objects[x][y].TakeDamage(damage)
+		q.AddCombatMessage(game.T("combat.hit", q.Objects[x][y].Name(), x, y, damage, deiptor))
+	}
 
 	if q.Objects[x][y].GetShields() <= 0 {
-		q.AddMessage(fmt.Sprintf("%s at %d, %d destroyed!", q.Objects[x][y].Name(), x, y))
-		switch q.Objects[x][y].(type) {
-		case *Klingon:
-			q.NumberOfKlingons--
-			q.Game.KlingonDestroyed()
-		}
-		q.Objects[x][y] = nil
+		q.AddCombatMessage(game.T("combat.destroyed", q.Objects[x][y].Name(), x, y))
+		q.destroyObjectAt(x, y)
+	}
+}
+
+// destroyObjectAt removes a destroyed object from the
+// quadrant, keeping the counts up to date
+func (q *Quadrant) destroyObjectAt(x int, y int) {
+	switch q.Objects[x][y].(type) {
+	case *Klingon:
+		q.NumberOfKlingons--
+		q.Game.KlingonDestroyed()
+	case *Starbase:
+		q.NumberOfStarbases--
+		q.Game.StarbaseDestroyed()
+	case *Romulan:
+		q.NumberOfRomulans--
+	case *Commander:
+		q.NumberOfKlingons--
+		q.Game.KlingonDestroyed()
+		q.Game.CommanderDestroyed(false)
+	case *SuperCommander:
+		q.NumberOfKlingons--
+		q.Game.KlingonDestroyed()
+		q.Game.CommanderDestroyed(true)
+	case *Tholian:
+		q.clearWeb()
 	}
+	q.Objects[x][y] = nil
 }
 
 func (q *Quadrant) updateTorpedoAt(ox int, oy int) {
@@ -210,83 +437,263 @@ func (q *Quadrant) updateTorpedoAt(ox int, oy int) {
 		return
 	}
 
+	// Plasma runs out of energy after a few sectors
+	if t.Plasma {
+		if t.Range <= 0 {
+			q.torpedoes[ox][oy] = nil
+			return
+		}
+		t.Range--
+	}
+
 	// Pick new location
-	x, y := newLocation(ox, oy, t.Direction)
+	x, y := NewLocation(ox, oy, t.Direction)
 
 	// Check if goes off the board
-	if x < 0 || x > 9 || y < 0 || y > 9 {
+	if !q.Size.Contains(x, y) {
+		q.torpedoes[ox][oy] = nil
+	} else if _, ok := q.Objects[x][y].(*BlackHole); ok {
+		q.AddCombatMessage(game.T("combat.torpedo_black_hole", x, y))
+		q.torpedoes[ox][oy] = nil
+	} else if w, ok := q.Objects[x][y].(*Wormhole); ok {
+		q.torpedoes[ox][oy] = nil
+		t.crossings++
+		switch {
+		case t.crossings > maxWormholeCrossings:
+			q.AddCombatMessage(game.T("combat.torpedo_wormhole", x, y))
+		case w.PartnerQuadrantX == q.X && w.PartnerQuadrantY == q.Y:
+			t.Move(w.PartnerX, w.PartnerY)
+			q.torpedoes[w.PartnerX][w.PartnerY] = t
+		case w.Partner == nil || w.Partner.Supernova:
+			q.AddCombatMessage(game.T("combat.torpedo_wormhole", x, y))
+		default:
+			q.AddCombatMessage(game.T("combat.torpedo_through", x, y, w.PartnerQuadrantX+1, w.PartnerQuadrantY+1))
+			w.Partner.flyTorpedo(t, w.PartnerX, w.PartnerY)
+		}
+	} else if _, ok := q.Objects[x][y].(*Star); ok && !t.Plasma && q.Game.GetRandom().CheckPercent(q.Game.GetRules().NovaPercent) {
 		q.torpedoes[ox][oy] = nil
+		q.Nova(x, y)
 	} else if q.Objects[x][y] != nil { // Has it hit anything?
-		q.damageObjectAt(x, y, TorpedoDamage, "torpedo")
+		if t.Plasma {
+			q.damageObjectAt(x, y, q.Game.GetRules().PlasmaDamage, game.T("weapon.plasma"), t.Direction)
+		} else if t.Damage > 0 {
+			q.damageObjectAt(x, y, t.Damage, game.T("weapon.torpedo"), t.Direction)
+		} else {
+			q.damageObjectAt(x, y, q.Game.GetRules().TorpedoDamage, game.T("weapon.torpedo"), t.Direction)
+		}
 		q.torpedoes[ox][oy] = nil
 	} else {
+		t.Move(x, y)
 		q.torpedoes[x][y] = t
 		q.torpedoes[ox][oy] = nil
 	}
 }
 
+// maxWormholeCrossings stops a torpedo caught between
+// wormholes from going round for ever
+const maxWormholeCrossings = 4
+
+// maxTorpedoFlight is further than a torpedo can fly across
+// the biggest quadrant
+const maxTorpedoFlight = 2 * game.MaxQuadrantSize
+
+// flyTorpedo follows a torpedo that has come through a
+// wormhole into the quadrant until it lands.  Only the
+// Enterprise's quadrant moves its torpedoes with the clock,
+// so in any other the torpedo flies all the way at once.
+func (q *Quadrant) flyTorpedo(t *Torpedo, x int, y int) {
+	t.Move(x, y)
+	q.torpedoes[x][y] = t
+	for i := 0; i < maxTorpedoFlight && q.torpedoes[t.X][t.Y] == t; i++ {
+		q.updateTorpedoAt(t.X, t.Y)
+	}
+	if q.torpedoes[t.X][t.Y] == t {
+		q.torpedoes[t.X][t.Y] = nil
+	}
+}
+
 func (q *Quadrant) klingonFireTorpedo(k *Klingon, dir int) {
-	ox, oy := k.Location()
-	q.AddMessage(fmt.Sprintf("Klingon at %d, %d is firing a torpedo!", ox, oy))
+	if k.Torpedoes <= 0 {
+		return
+	}
+	k.Torpedoes--
+	q.enemyFireTorpedo(k, dir)
+}
+
+func (q *Quadrant) enemyFireTorpedo(o Object, dir int) {
+	ox, oy := o.Location()
+	q.AddCombatMessage(game.T("combat.enemy_torpedo", o.Name(), ox, oy))
 	q.torpedoes[ox][oy] = &Torpedo{X: ox, Y: oy, Direction: dir}
 	q.updateTorpedoAt(ox, oy)
 }
 
+func (q *Quadrant) romulanFirePlasma(r *Romulan, dir int) {
+	r.Torpedoes--
+	ox, oy := r.Location()
+	q.AddCombatMessage(game.T("combat.romulan_plasma", ox, oy))
+	q.torpedoes[ox][oy] = &Torpedo{X: ox, Y: oy, Direction: dir, Plasma: true, Range: q.Game.GetRules().PlasmaRange}
+	q.updateTorpedoAt(ox, oy)
+}
+
+// inLineOfFire checks if a torpedo fired from the first
+// location could reach the second within the range given
+func inLineOfFire(x, y, tx, ty, maxRange int) bool {
+	dx, dy := game.Abs(tx-x), game.Abs(ty-y)
+	if dx != 0 && dy != 0 && dx != dy {
+		return false
+	}
+	return dx <= maxRange && dy <= maxRange
+}
+
+// romulanAction stalks the Enterprise under cloak, only
+// showing itself to fire when close enough for plasma
+func (q *Quadrant) romulanAction(r *Romulan) {
+	if !r.Cloaked {
+		r.Cloaked = true
+		return
+	}
+
+	x, y := r.Location()
+	rules := q.Game.GetRules()
+	if !q.Game.GetRandom().CheckPercent(rules.RomulanActionPercent) {
+		return
+	}
+
+	if r.Torpedoes > 0 && inLineOfFire(x, y, q.Player.X, q.Player.Y, rules.PlasmaRange) {
+		r.Cloaked = false
+		q.romulanFirePlasma(r, DirectionTo(x, y, q.Player.X, q.Player.Y))
+	} else {
+		q.MoveObject(r, DirectionTo(x, y, q.Player.X, q.Player.Y))
+	}
+}
+
+// commanderAction closes in on the Enterprise, lining up
+// on it and only firing when it has a shot
+func (q *Quadrant) commanderAction(o MoveableObject, torpedoes *int) {
+	if !q.Game.GetRandom().CheckPercent(q.Game.GetRules().CommanderActionPercent) {
+		return
+	}
+
+	x, y := o.Location()
+	px, py := q.Player.Location()
+	if *torpedoes > 0 && inLineOfFire(x, y, px, py, game.Max(q.Size.Width, q.Size.Height)) {
+		*torpedoes--
+		q.enemyFireTorpedo(o, DirectionTo(x, y, px, py))
+		return
+	}
+
+	// Close the smaller gap to line up a shot
+	dx, dy := game.Abs(px-x), game.Abs(py-y)
+	if dx < dy {
+		q.MoveObject(o, DirectionTo(x, y, px, y))
+	} else {
+		q.MoveObject(o, DirectionTo(x, y, x, py))
+	}
+}
+
+// DirectionTo returns the direction that best points from
+// the first location towards the second
+func DirectionTo(x, y, tx, ty int) int {
+	dx := x - tx
+	dy := y - ty
+	switch {
+	case dx < 0 && dy < 0:
+		return Dir3
+	case dx == 0 && dy < 0:
+		return Dir2
+	case dx > 0 && dy < 0:
+		return Dir1
+	case dx < 0 && dy == 0:
+		return Dir6
+	case dx > 0 && dy == 0:
+		return Dir4
+	case dx > 0 && dy > 0:
+		return Dir7
+	case dx == 0 && dy > 0:
+		return Dir8
+	case dx < 0 && dy > 0:
+		return Dir9
+	default:
+		return Dir5
+	}
+}
+
 func (q *Quadrant) klingonAction(k *Klingon) {
 	x, y := k.Location()
-	if game.CheckPercent(66) {
-		if game.CheckPercent(25) {
+	rules := q.Game.GetRules()
+	rnd := q.Game.GetRandom()
+	if rnd.CheckPercent(rules.KlingonActionPercent) {
+		if rnd.CheckPercent(rules.KlingonFirePercent) {
 			// Fire torpedo
-			dx := x - q.Player.X
-			dy := y - q.Player.Y
-			if dx < 0 && dy < 0 {
-				q.klingonFireTorpedo(k, 3)
-			} else if dx == 0 && dy < 0 {
-				q.klingonFireTorpedo(k, 2)
-			} else if dx > 0 && dy < 0 {
-				q.klingonFireTorpedo(k, 1)
-			} else if dx < 0 && dy == 0 {
-				q.klingonFireTorpedo(k, 6)
-			} else if dx > 0 && dy == 0 {
-				q.klingonFireTorpedo(k, 4)
-			} else if dx > 0 && dy > 0 {
-				q.klingonFireTorpedo(k, 7)
-			} else if dx == 0 && dy > 0 {
-				q.klingonFireTorpedo(k, 8)
-			} else if dx < 0 && dy > 0 {
-				q.klingonFireTorpedo(k, 9)
+			if dir := DirectionTo(x, y, q.Player.X, q.Player.Y); dir != Dir5 {
+				q.klingonFireTorpedo(k, dir)
 			}
 		} else {
 			// Move
 			var o MoveableObject = q.Objects[x][y].(MoveableObject)
-			q.MoveObject(o, game.RandomInt(9)+1)
+			q.MoveObject(o, rnd.RandomInt(9)+1)
 		}
 	}
 }
 
-// AddMessage adds a message to the messages display
-// Will be removed in 5 turns (stardate + 0.5)
+// AddMessage adds a message to the log
 func (q *Quadrant) AddMessage(t string) {
-	q.Messages = append(q.Messages, Message{Text: t, Stardate: q.Game.GetStardate()})
+	q.Game.GetLog().Add(q.Game.GetStardate(), game.Info, t)
+}
+
+// AddCombatMessage adds a report from the fighting to the log
+func (q *Quadrant) AddCombatMessage(t string) {
+	q.Game.GetLog().Add(q.Game.GetStardate(), game.Combat, t)
+}
+
+// AddAlert adds a message to the log that needs the
+// captain's attention
+func (q *Quadrant) AddAlert(t string) {
+	q.Game.GetLog().Add(q.Game.GetStardate(), game.Alert, t)
 }
 
 // Update processes the next turn for the quadrant
 func (q *Quadrant) Update() {
-	for x := 0; x < 10; x++ {
-		for y := 0; y < 10; y++ {
-			switch q.Objects[x][y].(type) {
-			case *Klingon:
-				q.klingonAction(q.Objects[x][y].(*Klingon))
+	q.drawPower()
+
+	// Find everyone first, so nothing that moves gets to act twice
+	var actors []Object
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			if q.Objects[x][y] != nil {
+				actors = append(actors, q.Objects[x][y])
 			}
 		}
 	}
 
+	for _, o := range actors {
+		// Skip anything destroyed earlier in the turn
+		if x, y := o.Location(); q.Objects[x][y] != o {
+			continue
+		}
+		switch obj := o.(type) {
+		case *Enterprise:
+			q.updateLandingParty()
+		case *Klingon:
+			q.klingonAction(obj)
+		case *Romulan:
+			q.romulanAction(obj)
+		case *Commander:
+			q.commanderAction(obj, &obj.Torpedoes)
+		case *SuperCommander:
+			q.commanderAction(obj, &obj.Torpedoes)
+		case *Tholian:
+			q.tholianAction(obj)
+		}
+	}
+
 	if q.playerDockedAtBase() {
-		q.Player.Energy += game.EnterpriseMaxEnergy * 0.25
+		q.Player.replaceCrew(game.EnterpriseMaxCrew / 10)
+		q.Player.Energy += game.EnterpriseMaxEnergy * q.Player.Efficiency() / 400
 		if q.Player.Energy > game.EnterpriseMaxEnergy {
 			q.Player.Energy = game.EnterpriseMaxEnergy
 		}
-		q.Player.Torpedoes += game.EnterpriseMaxTorpedoes * 0.25
+		q.Player.Torpedoes += game.EnterpriseMaxTorpedoes * q.Player.Efficiency() / 400
 		if q.Player.Torpedoes > game.EnterpriseMaxTorpedoes {
 			q.Player.Torpedoes = game.EnterpriseMaxTorpedoes
 		}
@@ -295,57 +702,112 @@ func (q *Quadrant) Update() {
 
 // DisplayQuadrant draws the Quadrant map
 func (q *Quadrant) DisplayQuadrant() {
-	QuadrantStr := fmt.Sprintf("Quadrant : %d, %d", q.X+1, q.Y+1)
-	game.EmitStr(22-(len(QuadrantStr)/2), 0, QuadrantStr)
-	game.EmitStr(3, 1, "=---=---=---=---=---=---=---=---=---=---")
-	for i := 0; i < 10; i++ {
-		q.displayQuadrantLine(i)
+	r := game.CurrentLayout().Map
+	QuadrantStr := game.T("map.quadrant", q.X+1, q.Y+1)
+	r.Emit(r.Width/2-(len(QuadrantStr)/2), 0, QuadrantStr)
+	r.Emit(3, 1, strings.Repeat("=---", q.Size.Width))
+	for i := 0; i < q.Size.Height; i++ {
+		q.displayQuadrantLine(r, i)
 	}
-	game.EmitStr(3, 12, "=-1-=-2-=-3-=-4-=-5-=-6-=-7-=-8-=-9-=-10")
+	numbers := ""
+	for i := 1; i <= q.Size.Width; i++ {
+		// Two digit numbers lose the dash after them
+		numbers += "=" + fmt.Sprintf("-%d-", i)[:3]
+	}
+	r.Emit(3, q.Size.Height+2, numbers)
+}
+
+// messageStyles are the theme elements that colour
+// the messages by severity
+var messageStyles = map[int]string{
+	game.Info:   "info",
+	game.Combat: "combat",
+	game.Alert:  "alert",
 }
 
-// DisplayMessages displays all the messages in order,
-// removing any greater than 0.5 Stardates old
+// DisplayMessages displays as much of the message log as
+// fits below the map, newest at the bottom
 func (q *Quadrant) DisplayMessages() {
-	for i, t := range q.Messages {
-		game.EmitStr(1, 16+i, fmt.Sprintf("Stardate %.1f: %s", t.Stardate, t.Text))
+	r := game.CurrentLayout().Messages
+	log := q.Game.GetLog()
+	messages, newer := log.Page(r.Height - 1)
+
+	header := game.T("log.header", game.T("filter."+game.FilterNames[log.Filter]))
+	if newer > 0 {
+		header += game.TN("log.newer", newer, newer)
 	}
+	r.Emit(0, 0, header)
 
-	// Remove older messages
-	for len(q.Messages) > 0 {
-		if q.Messages[0].Stardate < q.Game.GetStardate()-0.5 {
-			q.Messages = q.Messages[1:]
-		} else {
-			break
-		}
+	for i, m := range messages {
+		r.EmitStyle(0, 1+i, game.T("log.line", m.Stardate, m.Text), game.Style(messageStyles[m.Severity]))
+	}
+}
+
+func (q *Quadrant) displaySector(r game.Region, x int, y int) {
+	if objStr, element := q.sectorSymbol(x, y); objStr != "" {
+		r.EmitStyle(x*4+4, y+2, objStr, game.Style(element))
 	}
 }
 
-func (q *Quadrant) displaySector(x int, y int) {
+// SectorSymbol returns the three character symbol for what
+// can be seen in a sector, or "" if it looks empty
+func (q *Quadrant) SectorSymbol(x int, y int) string {
+	objStr, _ := q.sectorSymbol(x, y)
+	return objStr
+}
+
+// sectorSymbol returns the symbol for a sector and the
+// theme element to draw it in
+func (q *Quadrant) sectorSymbol(x int, y int) (string, string) {
 	if q.Objects[x][y] != nil {
-		objStr := ""
-		switch q.Objects[x][y].(type) {
+		objStr, element := "", ""
+		switch obj := q.Objects[x][y].(type) {
 		case Player:
-			objStr = "-E-"
+			objStr, element = q.enterpriseGlyph(), "enterprise"
 		case *Klingon:
-			objStr = "-K-"
+			element = "klingon"
 		case *Star:
-			objStr = " * "
+			element = "star"
 		case *Starbase:
-			objStr = ">B<"
+			element = "starbase"
+		case *Planet:
+			objStr, element = "("+obj.Class+")", "planet"
+		case *BlackHole:
+			element = "blackhole"
+		case *Wormhole:
+			element = "wormhole"
+		case *Tholian:
+			element = "tholian"
+		case *Web:
+			element = "web"
+		case *Commander:
+			element = "commander"
+		case *SuperCommander:
+			objStr, element = glyph("super"), "commander"
+		case *Romulan:
+			if obj.Cloaked {
+				return "", ""
+			}
+			element = "romulan"
+		}
+		if objStr == "" {
+			objStr = glyph(element)
 		}
-		game.EmitStr(x*4+4, y+2, objStr)
-	} else if q.torpedoes[x][y] != nil {
-		game.EmitStr(x*4+4, y+2, " @ ")
+		return objStr, element
+	} else if t := q.torpedoes[x][y]; t != nil {
+		if t.Plasma {
+			return glyph("plasma"), "plasma"
+		}
+		return glyph("torpedo"), "torpedo"
 	}
-
+	return "", ""
 }
 
-func (q *Quadrant) displayQuadrantLine(row int) {
-	game.EmitStr(0, row+2, fmt.Sprintf("%2d|                                        |", row+1))
+func (q *Quadrant) displayQuadrantLine(r game.Region, row int) {
+	r.Emit(0, row+2, fmt.Sprintf("%2d|%s|", row+1, strings.Repeat(" ", q.Size.Width*4)))
 
-	for i := 0; i < 10; i++ {
-		q.displaySector(i, row)
+	for i := 0; i < q.Size.Width; i++ {
+		q.displaySector(r, i, row)
 	}
 }
 
@@ -360,26 +822,140 @@ func (q *Quadrant) HandleKeyForState(key tcell.EventKey) {
 		case 't', 'T':
 			q.UpdateState(WeaponsTorpedoes)
 			q.Game.Draw()
+		case 'l', 'L':
+			q.probeArmed = false
+			q.UpdateState(ProbeCourse)
+		case 'a', 'A':
+			q.probeArmed = true
+			q.UpdateState(ProbeCourse)
 		}
-	case NavigationX:
-		num := int(key.Rune())
-		if num >= 49 && num <= 56 {
-			q.destinationX = num - 48
-			q.UpdateState(NavigationY)
+	case ShieldsMenu:
+		switch key.Rune() {
+		case 'r', 'R':
+			q.UpdateState(Shields)
+		case 'b', 'B':
+			q.BalanceShields()
+			q.Game.Spend(game.ActionShields)
+			q.UpdateState(Normal)
+		case 'f', 'F':
+			q.shieldArc = ArcFore
+			q.UpdateState(ShieldArc)
+		case 'a', 'A':
+			q.shieldArc = ArcAft
+			q.UpdateState(ShieldArc)
+		case 'p', 'P':
+			q.shieldArc = ArcPort
+			q.UpdateState(ShieldArc)
+		case 's', 'S':
+			q.shieldArc = ArcStarboard
+			q.UpdateState(ShieldArc)
 		}
+	case Planets:
+		var done bool
+		switch key.Rune() {
+		case 'o', 'O':
+			done = q.Orbit()
+		case 'b', 'B':
+			done = q.BeamDown()
+		case 't', 'T':
+			done = q.TakeShuttle()
+		case 'r', 'R':
+			done = q.RecallParty()
+		case 'd', 'D':
+			done = q.DilithiumBoost()
+		default:
+			return
+		}
+		if done {
+			q.Game.Spend(game.ActionPlanet)
+		}
+		q.UpdateState(Normal)
+	case NavigationX:
+		q.navigateX(int(key.Rune() - '0'))
 	case NavigationY:
-		num := int(key.Rune())
-		if num >= 49 && num <= 56 {
-			q.UpdateState(Normal)
-			d := game.Distance(q.X, q.Y, q.destinationX-1, num-49)
-			if q.Player.Energy < int(d*100) {
-				q.AddMessage("You do not have enough energy for that trip")
-			} else {
-				q.Player.Energy -= int(d * 100)
-				q.Game.NavigateTo(q.destinationX-1, num-49)
-			}
+		q.navigateY(int(key.Rune() - '0'))
+	}
+}
+
+// typedNavigation returns true if the galaxy is too big for
+// each destination coordinate to be a single key press, so
+// they must be typed in full and ended with Enter
+func (q *Quadrant) typedNavigation() bool {
+	size := q.Game.GetRules().Galaxy
+	return size.Width > 9 || size.Height > 9
+}
+
+// navigateX takes the X coordinate of the destination
+// quadrant, counting from 1
+func (q *Quadrant) navigateX(x int) {
+	if x >= 1 && x <= q.Game.GetRules().Galaxy.Width {
+		q.destinationX = x
+		q.UpdateState(NavigationY)
+	}
+}
+
+// navigateY takes the Y coordinate of the destination
+// quadrant, counting from 1, and warps there
+func (q *Quadrant) navigateY(y int) {
+	if y >= 1 && y <= q.Game.GetRules().Galaxy.Height {
+		q.UpdateState(Normal)
+		q.Warp(q.destinationX-1, y-1)
+	}
+}
+
+// Warp takes the Enterprise to the specified quadrant, if
+// it has enough energy for the trip
+func (q *Quadrant) Warp(x int, y int) bool {
+	if q.Trapped() {
+		q.AddAlert(game.T("alert.web_holds"))
+		return false
+	}
+	if !q.LeaveOrbit() {
+		return false
+	}
+	if s := q.Game.GetQuadrantSummary(x, y); s != nil && s.Supernova {
+		q.AddAlert(game.T("alert.warp_supernova"))
+		return false
+	} else if s != nil && s.Full {
+		q.AddAlert(game.T("alert.warp_full"))
+		return false
+	}
+	if !q.EnginesReady() {
+		return false
+	}
+	cost := q.WarpCost(q.X, q.Y, x, y)
+	if q.Player.Energy < cost {
+		q.AddMessage(game.T("msg.warp_energy"))
+		return false
+	}
+	q.Player.Energy -= cost
+	q.Game.NavigateTo(x, y)
+	return true
+}
+
+// WarpCost is the energy the engines take to warp from the
+// given quadrant to the other
+func (q *Quadrant) WarpCost(fromX, fromY, toX, toY int) int {
+	return q.Player.engineCost(int(game.Distance(fromX, fromY, toX, toY) * 100))
+}
+
+// FireTorpedo fires a photon torpedo from the Enterprise
+// in the given direction
+func (q *Quadrant) FireTorpedo(direction int) bool {
+	if q.Player.Torpedoes > 1 && direction >= 1 && direction <= 9 && direction != 5 {
+		if q.Player.WeaponPower == 0 {
+			q.AddAlert(game.T("alert.no_weapon_power"))
+			return false
 		}
+		q.Player.Torpedoes--
+		damage := q.Game.GetRules().TorpedoDamage * q.Player.Efficiency() / 100 * powerFactor(q.Player.WeaponPower) / 100
+		t := &Torpedo{X: q.Player.X, Y: q.Player.Y, Direction: direction, Damage: damage}
+		q.AddCombatMessage(game.T("combat.torpedo_fired"))
+		q.torpedoes[q.Player.X][q.Player.Y] = t
+		q.updateTorpedoAt(q.Player.X, q.Player.Y)
+		return true
 	}
+	return false
 }
 
 // AcceptInput accepts whatever the player has typed for input
@@ -387,21 +963,47 @@ func (q *Quadrant) AcceptInput() {
 	value, _ := strconv.Atoi(q.CurrentInput)
 	switch q.UIState {
 	case Shields:
-		if value > q.Player.Energy+q.Player.Shields {
-			q.Player.Shields = q.Player.Energy + q.Player.Shields
-			q.Player.Energy = 0
-		} else {
-			q.Player.Energy = (q.Player.Energy + q.Player.Shields) - value
-			q.Player.Shields = value
-		}
+		q.SetShields(value)
+		q.Game.Spend(game.ActionShields)
+	case ShieldArc:
+		q.SetShieldArc(q.shieldArc, value)
+		q.Game.Spend(game.ActionShields)
 	case WeaponsTorpedoes:
-		if q.Player.Torpedoes > 1 && value >= 1 && value <= 9 && value != 5 {
-			q.Player.Torpedoes--
-			t := &Torpedo{X: q.Player.X, Y: q.Player.Y, Direction: value}
-			q.AddMessage("Torpedo fired!")
-			q.torpedoes[q.Player.X][q.Player.Y] = t
-			q.updateTorpedoAt(q.Player.X, q.Player.Y)
+		if q.FireTorpedo(value) {
+			q.Game.Spend(game.ActionTorpedo)
+		}
+	case WeaponsPhasers:
+		if q.FirePhasers(value) {
+			q.Game.Spend(game.ActionPhasers)
 		}
+	case Power:
+		if q.AllocatePower(q.CurrentInput) {
+			q.Game.Spend(game.ActionPower)
+		}
+	case ProbeCourse:
+		if q.probeArmed {
+			q.probeCourse = value
+			q.UpdateState(ProbeRange)
+			return
+		}
+		if q.LaunchProbe(value, 0) {
+			q.Game.Spend(game.ActionProbe)
+		}
+	case ProbeRange:
+		if value > 0 && q.LaunchProbe(q.probeCourse, value) {
+			q.Game.Spend(game.ActionProbe)
+		}
+	case NavigationX:
+		// Anything out of range is cleared to be typed again
+		q.CurrentInput = ""
+		q.navigateX(value)
+		q.Game.Draw()
+		return
+	case NavigationY:
+		q.CurrentInput = ""
+		q.navigateY(value)
+		q.Game.Draw()
+		return
 	}
 	q.UpdateState(Normal)
 	q.Game.Draw()
@@ -410,54 +1012,132 @@ func (q *Quadrant) AcceptInput() {
 // DisplayState renders the bottom display
 // based on the state of the UI
 func (q *Quadrant) DisplayState() {
+	r := game.CurrentLayout().State
 	switch q.UIState {
 	case Normal:
-		game.EmitStr(1, 14, "(N)avigation   (W)eapons   (S)hields  (L)ong-Range Sensors  Ship's (C)omputer")
+		r.Emit(0, 0, game.T("menu.normal"))
+	case ShieldsMenu:
+		r.Emit(0, 0, game.T("menu.shields"))
 	case Shields:
-		game.EmitStr(1, 14, "Set energy for shields: ")
-		q.displayInput(25, 14)
+		q.displayPrompt(r, game.T("prompt.shields"))
+	case ShieldArc:
+		q.displayPrompt(r, game.T("prompt.shield_arc", game.T("arc."+ArcNames[q.shieldArc])))
 	case Weapons:
-		game.EmitStr(1, 14, "(P)hasers or Photon (T)orpedoes")
+		r.Emit(0, 0, game.T("menu.weapons"))
+	case Power:
+		q.displayPrompt(r, game.T("prompt.power", PowerUnits))
+	case Planets:
+		r.Emit(0, 0, game.T("menu.planets"))
 	case WeaponsTorpedoes:
-		game.EmitStr(1, 14, "Direction:")
-		q.displayInput(12, 14)
+		q.displayPrompt(r, game.T("prompt.direction"))
+	case WeaponsPhasers:
+		q.displayPrompt(r, game.T("prompt.phasers", q.Player.Energy))
+	case ProbeCourse:
+		q.displayPrompt(r, game.T("prompt.probe_course", q.Player.Probes))
+	case ProbeRange:
+		q.displayPrompt(r, game.T("prompt.probe_range"))
 	case NavigationX:
-		game.EmitStr(1, 14, "Destination Quadrant X:")
+		q.displayNavigation(r, game.T("prompt.quadrant_x"))
 	case NavigationY:
-		game.EmitStr(1, 14, "Destination Quadrant Y:")
+		q.displayNavigation(r, game.T("prompt.quadrant_y"))
 	}
 }
 
-func (q *Quadrant) displayInput(col int, row int) {
-	game.EmitStr(col, row, q.CurrentInput)
+// displayPrompt shows a prompt followed by what has
+// been typed so far
+func (q *Quadrant) displayPrompt(r game.Region, prompt string) {
+	r.Emit(0, 0, prompt+q.CurrentInput)
 	if q.blinkRed%2 == 0 {
-		game.EmitStr(col+len(q.CurrentInput), row, "_")
+		r.Emit(len(prompt)+len(q.CurrentInput), 0, "_")
 	}
 }
 
+// displayNavigation shows a prompt for a destination
+// coordinate, with what has been typed if it is typed in full
+func (q *Quadrant) displayNavigation(r game.Region, prompt string) {
+	if q.AwaitingInput {
+		q.displayPrompt(r, prompt+" ")
+	} else {
+		r.Emit(0, 0, prompt)
+	}
+}
+
+// hostiles counts the enemy ships the Enterprise knows about
+func (q *Quadrant) hostiles() int {
+	return q.NumberOfKlingons + q.VisibleRomulans()
+}
+
+// statusColumn is where the values in the status panel start
+const statusColumn = 18
+
+// statusLine draws a labelled line of the status panel
+func statusLine(r game.Region, row int, label string, value string) {
+	r.Emit(0, row, game.T(label))
+	r.Emit(statusColumn, row, value)
+}
+
+// Condition returns the ship's condition: DOCKED, RED when
+// there are enemies about, YELLOW when energy is low, or GREEN
+func (q *Quadrant) Condition() string {
+	switch {
+	case q.playerDockedAtBase():
+		return "DOCKED"
+	case q.hostiles() > 0:
+		return "RED"
+	case q.Player.Energy < game.EnterpriseMaxEnergy/5:
+		return "YELLOW"
+	}
+	return "GREEN"
+}
+
 // DisplayStatus draws the status of the quadrant (the stuff to the right of the map)
 func (q *Quadrant) DisplayStatus() {
+	r := game.CurrentLayout().Status
 	q.blinkRed++
-	game.EmitStr(49, 3, fmt.Sprintf("STARDATE:         %.1f", q.Game.GetStardate()))
-	game.EmitStr(49, 4, fmt.Sprintf("SECTOR:           %d,%d", q.Player.X, q.Player.Y))
+	statusLine(r, 1, "status.stardate", fmt.Sprintf("%.1f", q.Game.GetStardate()))
+	statusLine(r, 2, "status.sector", fmt.Sprintf("%d,%d", q.Player.X, q.Player.Y))
 
-	if q.playerDockedAtBase() {
-		game.EmitStr(49, 5, "CONDITION:        DOCKED")
-	} else if q.NumberOfKlingons > 0 && q.blinkRed%2 == 1 {
-		game.EmitStr(49, 5, "CONDITION:        RED")
-	} else if q.NumberOfKlingons > 0 {
-		game.EmitStr(49, 5, "CONDITION: ")
-	} else {
-		game.EmitStr(49, 5, "CONDITION:        GREEN")
+	statusLine(r, 3, "status.condition", "")
+	if condition := q.Condition(); condition != "RED" || q.blinkRed%2 == 1 {
+		r.EmitStyle(statusColumn, 3, game.T("condition."+condition), game.Style(strings.ToLower(condition)))
+	}
+
+	if q.Player.Energy > 0 {
+		statusLine(r, 4, "status.energy", strconv.Itoa(q.Player.Energy))
+	} else if q.blinkRed%2 == 1 {
+		statusLine(r, 4, "status.life_support", fmt.Sprintf("%.1f", float64(q.Player.LifeSupport)/10))
 	}
+	statusLine(r, 5, "status.torpedoes", strconv.Itoa(q.Player.Torpedoes))
+	statusLine(r, 6, "status.dilithium", strconv.Itoa(q.Player.Crystals))
+	statusLine(r, 7, "status.klingons", strconv.Itoa(q.Game.GetRemainingKlingons()))
+	statusLine(r, 8, "status.crew", fmt.Sprintf("%d/%d%%", q.Player.Crew, q.Player.Morale))
+	statusLine(r, 9, "status.power", fmt.Sprintf("%d/%d/%d", q.Player.EnginePower, q.Player.ShieldPower, q.Player.WeaponPower))
+	q.displayShields(r, 0, 10)
 
-	game.EmitStr(49, 6, fmt.Sprintf("SHIELDS:          %d", q.Player.Shields))
-	game.EmitStr(49, 7, fmt.Sprintf("ENERGY:           %d", q.Player.Energy))
-	game.EmitStr(49, 8, fmt.Sprintf("PHOTON TORPEDOES: %d", q.Player.Torpedoes))
-	game.EmitStr(49, 10, fmt.Sprintf("KLINGONS:         %d", q.Game.GetRemainingKlingons()))
+	switch {
+	case q.Player.Party == PartyLanding || q.Player.Party == PartyReturning:
+		r.Emit(0, 13, game.T("status.shuttle"))
+	case q.Player.Party == PartyDown:
+		r.Emit(0, 13, game.T("status.party", q.Player.PartyCrystals))
+	case q.Player.Orbiting:
+		r.Emit(0, 13, game.T("status.orbit"))
+	}
+}
+
+// displayShields draws the shield arcs around the ship,
+// with the bow at the top
+func (q *Quadrant) displayShields(r game.Region, col int, row int) {
+	arcs := q.Player.ShieldArcs
+	r.Emit(col, row, game.T("status.shields"))
+	r.Emit(col+17, row, fmt.Sprintf("%4d", arcs[ArcFore]))
+	r.Emit(col, row+1, game.T("status.heading")+" "+game.T("heading."+HeadingNames[q.Player.Heading]))
+	r.Emit(col+12, row+1, fmt.Sprintf("%4d %s %d", arcs[ArcPort], q.enterpriseGlyph(), arcs[ArcStarboard]))
+	r.Emit(col+17, row+2, fmt.Sprintf("%4d", arcs[ArcAft]))
 }
 
-func newLocation(ox int, oy int, direction int) (int, int) {
+// NewLocation returns the sector one step from the
+// original in the given direction
+func NewLocation(ox int, oy int, direction int) (int, int) {
 	x, y := ox, oy
 	switch direction {
 	case Dir1:
@@ -489,23 +1169,118 @@ func newLocation(ox int, oy int, direction int) (int, int) {
 // MoveObject moves the object in the direction specified
 func (q *Quadrant) MoveObject(m MoveableObject, direction int) {
 	ox, oy := m.Location()
-	x, y := newLocation(ox, oy, direction)
+	x, y := NewLocation(ox, oy, direction)
 
 	if x < 0 {
 		x = 0
-	} else if x > 9 {
-		x = 9
+	} else if x >= q.Size.Width {
+		x = q.Size.Width - 1
 	}
 
 	if y < 0 {
 		y = 0
-	} else if y > 9 {
-		y = 9
+	} else if y >= q.Size.Height {
+		y = q.Size.Height - 1
 	}
 
-	if q.Objects[x][y] == nil {
+	switch target := q.Objects[x][y].(type) {
+	case nil:
 		q.Objects[x][y] = m
 		q.Objects[ox][oy] = nil
 		m.Move(x, y)
+	case *BlackHole:
+		q.AddAlert(game.T("alert.black_hole", m.Name(), x, y))
+		if p, ok := m.(*Enterprise); ok {
+			q.Objects[ox][oy] = nil
+			p.Destroyed = true
+			p.Hits++
+		} else {
+			q.destroyObjectAt(ox, oy)
+		}
+	case *Wormhole:
+		q.throughWormhole(m, target)
+	}
+}
+
+// throughWormhole carries a ship to the wormhole's partner
+func (q *Quadrant) throughWormhole(m MoveableObject, w *Wormhole) {
+	ox, oy := m.Location()
+	if w.PartnerQuadrantX != q.X || w.PartnerQuadrantY != q.Y {
+		if _, ok := m.(*Enterprise); ok {
+			q.Game.WormholeTo(w.PartnerQuadrantX, w.PartnerQuadrantY, w.PartnerX, w.PartnerY)
+		} else {
+			q.leaveByWormhole(m, w)
+		}
+		return
+	}
+
+	x, y, ok := q.EmptyNeighbour(w.PartnerX, w.PartnerY)
+	if !ok {
+		return
+	}
+	q.Objects[x][y] = m
+	q.Objects[ox][oy] = nil
+	m.Move(x, y)
+	if _, ok := m.(*Enterprise); ok {
+		q.AddMessage(game.T("msg.wormhole_exit", w.PartnerX, w.PartnerY))
+	}
+}
+
+// leaveByWormhole takes a ship through a wormhole into
+// another quadrant, unless there is nowhere for it to go
+func (q *Quadrant) leaveByWormhole(m MoveableObject, w *Wormhole) {
+	to := w.Partner
+	if to == nil || to.Supernova {
+		return
+	}
+	x, y, ok := to.EmptyNeighbour(w.PartnerX, w.PartnerY)
+	if !ok {
+		return
+	}
+	q.RemoveObject(m)
+	to.enterObjectAt(m, x, y)
+	q.AddAlert(game.T("alert.ship_wormhole", m.Name(), w.X, w.Y))
+}
+
+// MoveRomulansAway moves any Romulans next to the given sector
+// to empty sectors further off, where there is room for them
+func (q *Quadrant) MoveRomulansAway(sx, sy int) {
+	var room [][2]int
+	for x := 0; x < q.Size.Width; x++ {
+		for y := 0; y < q.Size.Height; y++ {
+			if q.Objects[x][y] == nil && (game.Abs(x-sx) > 1 || game.Abs(y-sy) > 1) {
+				room = append(room, [2]int{x, y})
+			}
+		}
+	}
+
+	for x := sx - 1; x <= sx+1; x++ {
+		for y := sy - 1; y <= sy+1; y++ {
+			if !q.Size.Contains(x, y) || len(room) == 0 {
+				continue
+			}
+			r, ok := q.Objects[x][y].(*Romulan)
+			if !ok {
+				continue
+			}
+			i := q.Game.GetRandom().RandomInt(len(room))
+			to := room[i]
+			room = append(room[:i], room[i+1:]...)
+			q.Objects[x][y] = nil
+			q.Objects[to[0]][to[1]] = r
+			r.Move(to[0], to[1])
+		}
+	}
+}
+
+// EmptyNeighbour finds an empty sector next to the one given
+func (q *Quadrant) EmptyNeighbour(sx int, sy int) (int, int, bool) {
+	for x := sx - 1; x <= sx+1; x++ {
+		for y := sy - 1; y <= sy+1; y++ {
+			if q.Size.Contains(x, y) && q.Objects[x][y] == nil {
+				return x, y, true
+			}
+		}
 	}
+	return 0, 0, false
 }
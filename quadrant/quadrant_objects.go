@@ -19,3 +19,12 @@ type Object interface {
 	TakeDamage(damage int)
 	GetShields() int
 }
+
+// IsKlingon checks if the object is any kind of Klingon ship
+func IsKlingon(o Object) bool {
+	switch o.(type) {
+	case *Klingon, *Commander, *SuperCommander:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// Romulan warship, which can hide behind a cloaking device
+type Romulan struct {
+	X         int
+	Y         int
+	Shields   int
+	Torpedoes int
+	Cloaked   bool
+}
+
+// NewRomulan creates a new Romulan
+func NewRomulan(x int, y int) *Romulan {
+	return &Romulan{X: x, Y: y, Shields: 1200, Torpedoes: 6}
+}
+
+// Move the romulan
+func (r *Romulan) Move(x int, y int) {
+	r.X = x
+	r.Y = y
+}
+
+// TakeDamage does damage to romulan
+func (r *Romulan) TakeDamage(damage int) {
+	r.Shields -= damage
+}
+
+// Location returns the location of the Romulan
+func (r Romulan) Location() (int, int) {
+	return r.X, r.Y
+}
+
+// GetShields returns the object's shield strength
+func (r Romulan) GetShields() int {
+	return r.Shields
+}
+
+// Name returns the display-friendly name
+func (r Romulan) Name() string {
+	return game.T("name.romulan")
+}
@@ -0,0 +1,99 @@
+package quadrant
+
+import "github.com/hculpan/kabtrek/game"
+
+// Shield arcs, clockwise from the bow
+const (
+	ArcFore = iota
+	ArcStarboard
+	ArcAft
+	ArcPort
+)
+
+// ArcNames are the display names of the shield arcs
+var ArcNames = [4]string{"fore", "starboard", "aft", "port"}
+
+// HeadingNames are the compass names of each direction
+var HeadingNames = [10]string{"", "SW", "S", "SE", "W", "", "E", "NW", "N", "NE"}
+
+// compass turns a direction into eighths of a turn
+// clockwise from north
+func compass(direction int) int {
+	switch direction {
+	case Dir9:
+		return 1
+	case Dir6:
+		return 2
+	case Dir3:
+		return 3
+	case Dir2:
+		return 4
+	case Dir1:
+		return 5
+	case Dir4:
+		return 6
+	case Dir7:
+		return 7
+	}
+	return 0
+}
+
+// Shields returns the total strength of the shields
+func (e Enterprise) Shields() int {
+	result := 0
+	for _, s := range e.ShieldArcs {
+		result += s
+	}
+	return result
+}
+
+// ArcFacing returns the shield arc that takes a hit
+// travelling in the given direction
+func (e Enterprise) ArcFacing(direction int) int {
+	from := (compass(direction) + 4) % 8
+	switch (from - compass(e.Heading) + 8) % 8 {
+	case 7, 0, 1:
+		return ArcFore
+	case 2:
+		return ArcStarboard
+	case 3, 4, 5:
+		return ArcAft
+	}
+	return ArcPort
+}
+
+// SpreadShields shares the given strength evenly
+// between the arcs
+func (e *Enterprise) SpreadShields(total int) {
+	for i := range e.ShieldArcs {
+		e.ShieldArcs[i] = total / len(e.ShieldArcs)
+	}
+	e.ShieldArcs[ArcFore] += total % len(e.ShieldArcs)
+}
+
+// SetShields transfers energy to or from the shields,
+// spreading it evenly between the arcs
+func (q *Quadrant) SetShields(value int) {
+	total := q.Player.Energy + q.Player.Shields()
+	if value > total {
+		value = total
+	}
+	q.Player.Energy = total - value
+	q.Player.SpreadShields(value)
+}
+
+// SetShieldArc transfers energy to or from a single arc
+func (q *Quadrant) SetShieldArc(arc int, value int) {
+	total := q.Player.Energy + q.Player.ShieldArcs[arc]
+	if value > total {
+		value = total
+	}
+	q.Player.Energy = total - value
+	q.Player.ShieldArcs[arc] = value
+}
+
+// BalanceShields evens the shields out between the arcs
+func (q *Quadrant) BalanceShields() {
+	q.Player.SpreadShields(q.Player.Shields())
+	q.AddMessage(game.T("msg.shields_balanced"))
+}
@@ -1,8 +1,12 @@
 package quadrant
 
-import "math"
+import (
+	"math"
 
-// Star represents planets in the sector
+	"github.com/hculpan/kabtrek/game"
+)
+
+// Star represents a star in the sector
 type Star struct {
 	X int
 	Y int
@@ -25,5 +29,5 @@ func (s Star) GetShields() int {
 
 // Name returns the display-friendly name
 func (s Star) Name() string {
-	return "Star"
+	return game.T("name.star")
 }
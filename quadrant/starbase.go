@@ -1,6 +1,8 @@
 package quadrant
 
-// Starbase represents planets in the sector
+import "github.com/hculpan/kabtrek/game"
+
+// Starbase represents a Federation starbase in the sector
 type Starbase struct {
 	X       int
 	Y       int
@@ -15,12 +17,12 @@ func NewStarbase(x int, y int) *Starbase {
 		Shields: 10000}
 }
 
-// Location returns the location of the planet in the quadrant
+// Location returns the location of the starbase in the quadrant
 func (s Starbase) Location() (int, int) {
 	return s.X, s.Y
 }
 
-// TakeDamage does damage to klingon
+// TakeDamage does damage to starbase
 func (s *Starbase) TakeDamage(damage int) {
 	s.Shields -= damage
 }
@@ -32,5 +34,5 @@ func (s Starbase) GetShields() int {
 
 // Name returns the display-friendly name
 func (s Starbase) Name() string {
-	return "Starbase"
+	return game.T("name.starbase")
 }
@@ -0,0 +1,181 @@
+package quadrant
+
+import (
+	"math"
+
+	"github.com/hculpan/kabtrek/game"
+)
+
+// Tholian creeps around the edge of a quadrant, spinning
+// a web behind it
+type Tholian struct {
+	X       int
+	Y       int
+	Shields int
+
+	// WebClosed is set once the web runs all the way round
+	WebClosed bool
+}
+
+// NewTholian creates a new Tholian
+func NewTholian(x int, y int) *Tholian {
+	return &Tholian{X: x, Y: y, Shields: 500}
+}
+
+// Move the Tholian
+func (t *Tholian) Move(x int, y int) {
+	t.X = x
+	t.Y = y
+}
+
+// TakeDamage does damage to the Tholian
+func (t *Tholian) TakeDamage(damage int) {
+	t.Shields -= damage
+}
+
+// Location returns the location of the Tholian
+func (t Tholian) Location() (int, int) {
+	return t.X, t.Y
+}
+
+// GetShields returns the object's shield strength
+func (t Tholian) GetShields() int {
+	return t.Shields
+}
+
+// Name returns the display-friendly name
+func (t Tholian) Name() string {
+	return game.T("name.tholian")
+}
+
+// Web is a strand of Tholian web, which nothing can pass
+type Web struct {
+	X int
+	Y int
+}
+
+// Location returns the location of the web
+func (w Web) Location() (int, int) {
+	return w.X, w.Y
+}
+
+// TakeDamage does no damage to web
+func (w *Web) TakeDamage(damage int) {
+	// webs only go when the Tholian does
+}
+
+// GetShields returns the object's shield strength
+func (w Web) GetShields() int {
+	return math.MaxInt64
+}
+
+// Name returns the display-friendly name
+func (w Web) Name() string {
+	return game.T("name.web")
+}
+
+// border lists the sectors around the edge of the quadrant,
+// clockwise from the top left corner
+func (q *Quadrant) border() [][2]int {
+	var result [][2]int
+	right, bottom := q.Size.Width-1, q.Size.Height-1
+	for x := 0; x < right; x++ {
+		result = append(result, [2]int{x, 0})
+	}
+	for y := 0; y < bottom; y++ {
+		result = append(result, [2]int{right, y})
+	}
+	for x := right; x > 0; x-- {
+		result = append(result, [2]int{x, bottom})
+	}
+	for y := bottom; y > 0; y-- {
+		result = append(result, [2]int{0, y})
+	}
+	return result
+}
+
+// AddTholian brings a Tholian into an empty corner of the
+// quadrant, unless there is one here already
+func (q *Quadrant) AddTholian() bool {
+	if q.tholian() != nil {
+		return false
+	}
+	right, bottom := q.Size.Width-1, q.Size.Height-1
+	corners := [][2]int{{0, 0}, {right, 0}, {right, bottom}, {0, bottom}}
+	start := q.Game.GetRandom().RandomInt(len(corners))
+	for i := range corners {
+		c := corners[(start+i)%len(corners)]
+		if q.Objects[c[0]][c[1]] == nil {
+			q.AddObject(NewTholian(c[0], c[1]))
+			q.AddAlert(game.T("alert.tholian", c[0], c[1]))
+			return true
+		}
+	}
+	return false
+}
+
+// tholian returns the Tholian in the quadrant, if there is one
+func (q *Quadrant) tholian() *Tholian {
+	for _, c := range q.border() {
+		if t, ok := q.Objects[c[0]][c[1]].(*Tholian); ok {
+			return t
+		}
+	}
+	return nil
+}
+
+// Trapped checks if the Tholian web has closed around the Enterprise
+func (q *Quadrant) Trapped() bool {
+	t := q.tholian()
+	return t != nil && t.WebClosed
+}
+
+// tholianAction moves the Tholian on to the next free sector
+// of the border, leaving web behind it.  It waits for any ship
+// in its way, and passes over anything else.
+func (q *Quadrant) tholianAction(t *Tholian) {
+	if t.WebClosed {
+		return
+	}
+
+	path := q.border()
+	start := 0
+	for i, c := range path {
+		if c[0] == t.X && c[1] == t.Y {
+			start = i
+		}
+	}
+
+	for i := 1; i < len(path); i++ {
+		c := path[(start+i)%len(path)]
+		switch q.Objects[c[0]][c[1]].(type) {
+		case nil:
+			ox, oy := t.Location()
+			q.Objects[ox][oy] = &Web{X: ox, Y: oy}
+			q.Objects[c[0]][c[1]] = t
+			t.Move(c[0], c[1])
+			return
+		case MoveableObject:
+			return
+		}
+	}
+
+	t.WebClosed = true
+	q.AddAlert(game.T("alert.trapped"))
+}
+
+// clearWeb removes every strand of web from the quadrant
+func (q *Quadrant) clearWeb() {
+	found := false
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			if _, ok := q.Objects[x][y].(*Web); ok {
+				q.Objects[x][y] = nil
+				found = true
+			}
+		}
+	}
+	if found {
+		q.AddMessage(game.T("msg.web_dissolves"))
+	}
+}
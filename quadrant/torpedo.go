@@ -5,6 +5,17 @@ type Torpedo struct {
 	X         int
 	Y         int
 	Direction int
+
+	// Plasma torpedoes are fired by Romulans, and only
+	// travel Range sectors before they dissipate
+	Plasma bool
+	Range  int
+
+	// Damage is set for torpedoes fired by the Enterprise,
+	// where it depends on how well the crew loads them
+	Damage int
+
+	crossings int
 }
 
 // Move the torpedo
@@ -0,0 +1,414 @@
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hculpan/kabtrek/galaxy"
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/quadrant"
+)
+
+// DefaultSectors is the size of each quadrant when a
+// scenario does not give one.  Coordinates in scenario
+// files are 1-based, as they are shown in the game.
+var DefaultSectors = Size{Width: 10, Height: 10}
+
+// Coord is a 1-based x, y pair, written as [x, y]
+type Coord [2]int
+
+// Scenario is a hand-authored game setup
+type Scenario struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Galaxy      Size       `json:"galaxy"`
+	Sectors     *Size      `json:"sectors"`
+	Stardate    float64    `json:"stardate"`
+	Enterprise  Ship       `json:"enterprise"`
+	Quadrants   []Quadrant `json:"quadrants"`
+	Wormholes   []Wormhole `json:"wormholes"`
+	Win         Win        `json:"win"`
+	Lose        Lose       `json:"lose"`
+}
+
+// Size is the width and height of the galaxy in quadrants,
+// or of a quadrant in sectors
+type Size struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+func (s Size) dimensions() game.Dimensions {
+	return game.Dimensions{Width: s.Width, Height: s.Height}
+}
+
+// QuadrantSize returns the number of sectors in each quadrant
+func (s *Scenario) QuadrantSize() game.Dimensions {
+	if s.Sectors == nil {
+		return DefaultSectors.dimensions()
+	}
+	return s.Sectors.dimensions()
+}
+
+// Ship is the Enterprise's starting position and resources.
+// Resources left at zero get the usual starting values.
+type Ship struct {
+	Quadrant  Coord `json:"quadrant"`
+	Sector    Coord `json:"sector"`
+	Energy    int   `json:"energy"`
+	Shields   int   `json:"shields"`
+	Torpedoes int   `json:"torpedoes"`
+}
+
+// Quadrant lists the objects placed in one quadrant.  Any
+// quadrant not listed is empty space.
+type Quadrant struct {
+	Quadrant        Coord      `json:"quadrant"`
+	Klingons        []Klingon  `json:"klingons"`
+	Commanders      []Klingon  `json:"commanders"`
+	SuperCommanders []Klingon  `json:"super_commanders"`
+	Romulans        []Romulan  `json:"romulans"`
+	Stars           []Coord    `json:"stars"`
+	BlackHoles      []Coord    `json:"black_holes"`
+	Planets         []Planet   `json:"planets"`
+	Starbases       []Starbase `json:"starbases"`
+}
+
+// Place is a sector in a particular quadrant
+type Place struct {
+	Quadrant Coord `json:"quadrant"`
+	Sector   Coord `json:"sector"`
+}
+
+// Wormhole links two places, in the same or different quadrants
+type Wormhole struct {
+	From Place `json:"from"`
+	To   Place `json:"to"`
+}
+
+// Klingon is a placed Klingon; values left out get the usual
+// defaults
+type Klingon struct {
+	Sector    Coord `json:"sector"`
+	Shields   *int  `json:"shields"`
+	Torpedoes *int  `json:"torpedoes"`
+}
+
+// Romulan is a placed Romulan; values left out get the usual
+// defaults
+type Romulan struct {
+	Sector    Coord `json:"sector"`
+	Shields   *int  `json:"shields"`
+	Torpedoes *int  `json:"torpedoes"`
+	Cloaked   bool  `json:"cloaked"`
+}
+
+// Planet is a placed planet of class M, N or O
+type Planet struct {
+	Sector    Coord  `json:"sector"`
+	Class     string `json:"class"`
+	Dilithium int    `json:"dilithium"`
+}
+
+// Starbase is a placed starbase; zero shields get the usual default
+type Starbase struct {
+	Sector  Coord `json:"sector"`
+	Shields int   `json:"shields"`
+}
+
+// Win holds the victory conditions
+type Win struct {
+	// KlingonsRemaining is how many Klingons may survive
+	KlingonsRemaining int `json:"klingons_remaining"`
+}
+
+// Lose holds the defeat conditions, beyond losing the ship
+type Lose struct {
+	// TimeLimit is the number of stardates allowed, or 0 for no limit
+	TimeLimit float64 `json:"time_limit"`
+
+	// MinimumStarbases is how many starbases must survive
+	MinimumStarbases int `json:"minimum_starbases"`
+}
+
+// ValidationError lists everything wrong with a scenario
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid scenario:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// Load reads and validates a scenario file
+func Load(path string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Scenario{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return result, nil
+}
+
+// Validate checks coordinates are in range and that no two
+// objects share a sector
+func (s *Scenario) Validate() error {
+	v := &validator{
+		galaxy:   s.Galaxy.dimensions(),
+		quadrant: s.QuadrantSize(),
+		occupied: map[[4]int]string{},
+	}
+
+	if !v.galaxy.Within(game.MinGalaxySize, game.MaxGalaxySize) {
+		v.addf("galaxy size %s is not supported, it must be between %d and %d each way", v.galaxy, game.MinGalaxySize, game.MaxGalaxySize)
+	}
+	if !v.quadrant.Within(game.MinQuadrantSize, game.MaxQuadrantSize) {
+		v.addf("quadrant size %s is not supported, it must be between %d and %d each way", v.quadrant, game.MinQuadrantSize, game.MaxQuadrantSize)
+	}
+
+	e := s.Enterprise
+	v.checkQuadrant("Enterprise", e.Quadrant)
+	v.place("Enterprise", e.Quadrant, e.Sector)
+	v.checkNotNegative("Enterprise energy", e.Energy)
+	v.checkNotNegative("Enterprise shields", e.Shields)
+	v.checkNotNegative("Enterprise torpedoes", e.Torpedoes)
+
+	totalKlingons, totalStarbases := 0, 0
+	for _, q := range s.Quadrants {
+		where := fmt.Sprintf("quadrant %d,%d", q.Quadrant[0], q.Quadrant[1])
+		v.checkQuadrant(where, q.Quadrant)
+		for _, k := range q.Klingons {
+			v.place("Klingon in "+where, q.Quadrant, k.Sector)
+			v.checkOptional("Klingon shields in "+where, k.Shields)
+			v.checkOptional("Klingon torpedoes in "+where, k.Torpedoes)
+		}
+		for _, k := range q.Commanders {
+			v.place("Commander in "+where, q.Quadrant, k.Sector)
+			v.checkOptional("Commander shields in "+where, k.Shields)
+			v.checkOptional("Commander torpedoes in "+where, k.Torpedoes)
+		}
+		for _, k := range q.SuperCommanders {
+			v.place("Super-Commander in "+where, q.Quadrant, k.Sector)
+			v.checkOptional("Super-Commander shields in "+where, k.Shields)
+			v.checkOptional("Super-Commander torpedoes in "+where, k.Torpedoes)
+		}
+		for _, r := range q.Romulans {
+			v.place("Romulan in "+where, q.Quadrant, r.Sector)
+			v.checkOptional("Romulan shields in "+where, r.Shields)
+			v.checkOptional("Romulan torpedoes in "+where, r.Torpedoes)
+		}
+		for _, st := range q.Stars {
+			v.place("star in "+where, q.Quadrant, st)
+		}
+		for _, b := range q.BlackHoles {
+			v.place("black hole in "+where, q.Quadrant, b)
+		}
+		for _, p := range q.Planets {
+			v.place("planet in "+where, q.Quadrant, p.Sector)
+			v.checkNotNegative("planet dilithium in "+where, p.Dilithium)
+			if p.Class != quadrant.ClassM && p.Class != quadrant.ClassN && p.Class != quadrant.ClassO {
+				v.addf("planet in %s: class %q should be M, N or O", where, p.Class)
+			}
+		}
+		for _, b := range q.Starbases {
+			v.place("starbase in "+where, q.Quadrant, b.Sector)
+			v.checkNotNegative("starbase shields in "+where, b.Shields)
+		}
+		totalKlingons += len(q.Klingons) + len(q.Commanders) + len(q.SuperCommanders)
+		totalStarbases += len(q.Starbases)
+	}
+
+	for i, w := range s.Wormholes {
+		for _, end := range []Place{w.From, w.To} {
+			what := fmt.Sprintf("wormhole %d", i+1)
+			if v.checkQuadrant(what, end.Quadrant) {
+				v.place(what, end.Quadrant, end.Sector)
+			}
+		}
+	}
+
+	if totalKlingons <= s.Win.KlingonsRemaining {
+		v.addf("win condition allows %d Klingons to remain, but only %d are placed", s.Win.KlingonsRemaining, totalKlingons)
+	}
+	if s.Lose.MinimumStarbases > totalStarbases {
+		v.addf("lose condition needs %d starbases, but only %d are placed", s.Lose.MinimumStarbases, totalStarbases)
+	}
+	if s.Lose.TimeLimit < 0 {
+		v.addf("time limit cannot be negative (%.1f)", s.Lose.TimeLimit)
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+type validator struct {
+	problems []string
+	galaxy   game.Dimensions
+	quadrant game.Dimensions
+	occupied map[[4]int]string
+}
+
+func (v *validator) addf(format string, a ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, a...))
+}
+
+func (v *validator) checkNotNegative(what string, n int) {
+	if n < 0 {
+		v.addf("%s cannot be negative (%d)", what, n)
+	}
+}
+
+// checkOptional checks a value that may be left out
+func (v *validator) checkOptional(what string, n *int) {
+	if n != nil {
+		v.checkNotNegative(what, *n)
+	}
+}
+
+func (v *validator) checkQuadrant(what string, c Coord) bool {
+	if !inRange(c, v.galaxy) {
+		v.addf("%s: quadrant %d,%d is outside the galaxy (1-%d, 1-%d)", what, c[0], c[1], v.galaxy.Width, v.galaxy.Height)
+		return false
+	}
+	return true
+}
+
+func (v *validator) place(what string, q Coord, s Coord) {
+	if !inRange(q, v.galaxy) {
+		return
+	}
+	if !inRange(s, v.quadrant) {
+		v.addf("%s: sector %d,%d is outside the quadrant (1-%d, 1-%d)", what, s[0], s[1], v.quadrant.Width, v.quadrant.Height)
+		return
+	}
+
+	key := [4]int{q[0], q[1], s[0], s[1]}
+	if other, ok := v.occupied[key]; ok {
+		v.addf("%s: sector %d,%d of quadrant %d,%d is already taken by the %s", what, s[0], s[1], q[0], q[1], other)
+		return
+	}
+	v.occupied[key] = what
+}
+
+func inRange(c Coord, size game.Dimensions) bool {
+	return size.Contains(c[0]-1, c[1]-1)
+}
+
+// Build creates a galaxy from the scenario, with the
+// Enterprise in place
+func (s *Scenario) Build(rules *game.Rules, rnd *game.Random) *galaxy.Galaxy {
+	totalKlingons, totalStarbases := 0, 0
+	for _, q := range s.Quadrants {
+		totalKlingons += len(q.Klingons)
+		totalStarbases += len(q.Starbases)
+	}
+
+	r := *rules
+	r.Galaxy, r.Quadrant = s.Galaxy.dimensions(), s.QuadrantSize()
+	g := galaxy.NewEmptyGalaxy(totalKlingons, totalStarbases, &r, rnd)
+	if s.Stardate > 0 {
+		g.Stardate = s.Stardate
+		g.StartingStardate = s.Stardate
+	}
+	g.Conditions = game.Conditions{
+		KlingonsRemaining: s.Win.KlingonsRemaining,
+		TimeLimit:         s.Lose.TimeLimit,
+		MinimumStarbases:  s.Lose.MinimumStarbases,
+	}
+
+	for _, sq := range s.Quadrants {
+		q := &g.Quadrants[sq.Quadrant[0]-1][sq.Quadrant[1]-1]
+		for _, k := range sq.Klingons {
+			o := quadrant.NewKlingon(k.Sector[0]-1, k.Sector[1]-1)
+			if k.Shields != nil {
+				o.Shields = *k.Shields
+				o.FullShields = *k.Shields
+			}
+			if k.Torpedoes != nil {
+				o.Torpedoes = *k.Torpedoes
+			}
+			q.AddObject(o)
+		}
+		for _, k := range sq.Commanders {
+			o := quadrant.NewCommander(k.Sector[0]-1, k.Sector[1]-1)
+			if k.Shields != nil {
+				o.Shields = *k.Shields
+				o.FullShields = *k.Shields
+			}
+			if k.Torpedoes != nil {
+				o.Torpedoes = *k.Torpedoes
+			}
+			g.AddCommander(q, o)
+		}
+		for _, k := range sq.SuperCommanders {
+			o := quadrant.NewSuperCommander(k.Sector[0]-1, k.Sector[1]-1)
+			if k.Shields != nil {
+				o.Shields = *k.Shields
+				o.FullShields = *k.Shields
+			}
+			if k.Torpedoes != nil {
+				o.Torpedoes = *k.Torpedoes
+			}
+			g.AddCommander(q, o)
+		}
+		for _, r := range sq.Romulans {
+			o := quadrant.NewRomulan(r.Sector[0]-1, r.Sector[1]-1)
+			if r.Shields != nil {
+				o.Shields = *r.Shields
+			}
+			if r.Torpedoes != nil {
+				o.Torpedoes = *r.Torpedoes
+			}
+			o.Cloaked = r.Cloaked
+			q.AddObject(o)
+		}
+		for _, st := range sq.Stars {
+			q.AddObject(&quadrant.Star{X: st[0] - 1, Y: st[1] - 1})
+		}
+		for _, b := range sq.BlackHoles {
+			q.AddObject(&quadrant.BlackHole{X: b[0] - 1, Y: b[1] - 1})
+		}
+		for _, p := range sq.Planets {
+			q.AddObject(quadrant.NewPlanet(p.Sector[0]-1, p.Sector[1]-1, p.Class, p.Dilithium))
+		}
+		for _, b := range sq.Starbases {
+			o := quadrant.NewStarbase(b.Sector[0]-1, b.Sector[1]-1)
+			if b.Shields > 0 {
+				o.Shields = b.Shields
+			}
+			q.AddObject(o)
+		}
+	}
+
+	for _, w := range s.Wormholes {
+		from, to := w.From, w.To
+		o := &quadrant.Wormhole{X: from.Sector[0] - 1, Y: from.Sector[1] - 1}
+		g.Quadrants[from.Quadrant[0]-1][from.Quadrant[1]-1].AddObject(o)
+		g.LinkWormholes(o, from.Quadrant[0]-1, from.Quadrant[1]-1, to.Sector[0]-1, to.Sector[1]-1, to.Quadrant[0]-1, to.Quadrant[1]-1)
+	}
+
+	e := s.Enterprise
+	g.Player = quadrant.NewEnterprise(e.Sector[0]-1, e.Sector[1]-1)
+	if e.Energy > 0 {
+		g.Player.Energy = e.Energy
+	}
+	g.Player.SpreadShields(e.Shields)
+	if e.Torpedoes > 0 {
+		g.Player.Torpedoes = e.Torpedoes
+	}
+	g.SetActiveQuadrantAt(e.Quadrant[0]-1, e.Quadrant[1]-1, e.Sector[0]-1, e.Sector[1]-1)
+	g.ScanNeighborQuadrants()
+
+	return g
+}
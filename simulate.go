@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/scenario"
+	"github.com/hculpan/kabtrek/simulate"
+)
+
+// runSimulate handles the "simulate" subcommand, returning
+// the exit code
+func runSimulate(args []string) int {
+	rules := game.DefaultRules()
+
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	games := fs.Int("games", 1000, "number of games to play")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of games to play at once")
+	seed := fs.Int64("seed", 1, "seed for the first game; each game after adds one")
+	bot := fs.String("bot", "hunter", fmt.Sprintf("bot to play with (%s)", strings.Join(simulate.BotNames, ", ")))
+	klingons := fs.Int("klingons", 25, "ordinary Klingons in the galaxy, scaled to its size if not given; the Commanders and Super-Commanders come on top")
+	starbases := fs.Int("starbases", 5, "starbases in the galaxy, scaled to its size if not given")
+	galaxySize := fs.String("galaxy", rules.Galaxy.String(), "size of the galaxy in quadrants, as WxH")
+	quadrantSize := fs.String("quadrant", rules.Quadrant.String(), "size of each quadrant in sectors, as WxH")
+	maxStardates := fs.Float64("max-stardates", 500, "stardates before a game is called off")
+	scenarioFile := fs.String("scenario", "", "JSON scenario file to play instead of random galaxies")
+	csvFile := fs.String("csv", "", "file to write per-game results to")
+	distribution := fs.String("distribution", joinInts(rules.KlingonDistribution), "cumulative percentages for 0, 1, 2... Klingons per quadrant")
+	fs.IntVar(&rules.KlingonActionPercent, "klingon-action", rules.KlingonActionPercent, "percent chance a Klingon acts each turn")
+	fs.IntVar(&rules.KlingonFirePercent, "klingon-fire", rules.KlingonFirePercent, "percent chance an acting Klingon fires")
+	romulans := fs.Int("romulans", rules.Romulans, "Romulan warships in the galaxy, scaled to its size if not given")
+	commanders := fs.Int("commanders", rules.Commanders, "Klingon Commanders in the galaxy, scaled to its size if not given")
+	superCommanders := fs.Int("super-commanders", rules.SuperCommanders, "Klingon Super-Commanders in the galaxy, scaled to its size if not given")
+	fs.IntVar(&rules.PlasmaDamage, "plasma-damage", rules.PlasmaDamage, "damage done by a Romulan plasma torpedo")
+	fs.IntVar(&rules.TorpedoDamage, "torpedo-damage", rules.TorpedoDamage, "damage done by a photon torpedo")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	d, err := splitInts(*distribution)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -distribution: %v\n", err)
+		return 2
+	}
+	rules.KlingonDistribution = d
+
+	gs, err := game.ParseDimensions(*galaxySize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -galaxy: %v\n", err)
+		return 2
+	}
+	qs, err := game.ParseDimensions(*quadrantSize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -quadrant: %v\n", err)
+		return 2
+	}
+
+	// Everything is scaled to the size of the galaxy, and then
+	// any numbers given outright take the place of the scaled ones
+	rules.Resize(gs, qs)
+	given := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	if !given["klingons"] {
+		*klingons = rules.Scale(*klingons)
+	}
+	if !given["starbases"] {
+		*starbases = rules.Scale(*starbases)
+	}
+	if given["romulans"] {
+		rules.Romulans = *romulans
+	}
+	if given["commanders"] {
+		rules.Commanders = *commanders
+	}
+	if given["super-commanders"] {
+		rules.SuperCommanders = *superCommanders
+	}
+	if err := rules.CheckSizes(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	var s *scenario.Scenario
+	if *scenarioFile != "" {
+		if s, err = scenario.Load(*scenarioFile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+
+	results, err := simulate.Run(simulate.Config{
+		Games:        *games,
+		Workers:      *workers,
+		Seed:         *seed,
+		Bot:          *bot,
+		Klingons:     *klingons,
+		Starbases:    *starbases,
+		MaxStardates: *maxStardates,
+		Rules:        rules,
+		Scenario:     s,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	simulate.WriteTable(os.Stdout, simulate.Summarize(results))
+
+	if *csvFile != "" {
+		f, err := os.Create(*csvFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer f.Close()
+		if err := simulate.WriteCSV(f, results); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func joinInts(n []int) string {
+	s := make([]string, len(n))
+	for i, v := range n {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ",")
+}
+
+func splitInts(s string) ([]int, error) {
+	var result []int
+	for _, f := range strings.Split(s, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
@@ -0,0 +1,448 @@
+package simulate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hculpan/kabtrek/galaxy"
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/quadrant"
+)
+
+// Bot plays the part of the captain in a headless game
+type Bot interface {
+	// Act is called once per tick of the game clock
+	Act(g *galaxy.Galaxy)
+}
+
+// BotNames lists the bots that can be selected by name
+var BotNames = []string{"idle", "random", "hunter"}
+
+// NewBot returns the bot with the given name
+func NewBot(name string) (Bot, error) {
+	switch name {
+	case "idle":
+		return &IdleBot{}, nil
+	case "random":
+		return &RandomBot{}, nil
+	case "hunter":
+		return &HunterBot{}, nil
+	}
+	return nil, fmt.Errorf("unknown bot %q, expected one of %v", name, BotNames)
+}
+
+// IdleBot raises shields and then sits still, which is a
+// useful baseline for how dangerous the Klingons are
+type IdleBot struct{}
+
+// Act raises the shields once
+func (b *IdleBot) Act(g *galaxy.Galaxy) {
+	if g.Player.Shields() == 0 && g.Player.Energy > 1000 {
+		g.GetActiveQuadrant().SetShields(1000)
+	}
+}
+
+// RandomBot mashes keys, moving and firing in random directions
+type RandomBot struct{}
+
+// Act picks a random action
+func (b *RandomBot) Act(g *galaxy.Galaxy) {
+	q := g.GetActiveQuadrant()
+	n := g.Random.GetPercent()
+	switch {
+	case n < 30:
+		g.MovePlayer(g.Random.RandomInt(9) + 1)
+	case n < 40:
+		q.FireTorpedo(g.Random.RandomInt(9) + 1)
+	case n < 42 && q.NumberOfKlingons == 0:
+		q.SetShields(0)
+		q.Warp(g.Random.RandomInt(g.Rules.Galaxy.Width), g.Random.RandomInt(g.Rules.Galaxy.Height))
+	}
+}
+
+// HunterBot plays a sensible game: it warps to the nearest
+// known Klingons, lines up shots, keeps its shields topped up
+// and falls back on a starbase when running low
+type HunterBot struct {
+	// resupplying is set while it makes for a starbase to
+	// fill up
+	resupplying bool
+}
+
+// How the hunter manages its ship
+const (
+	// huntShields is what it keeps in each shield arc, enough
+	// to take a torpedo without it getting through
+	huntShields = 600
+
+	// huntReserve is the energy it keeps back, as the shields
+	// fail if the energy runs out
+	huntReserve = 500
+
+	// retreatEnergy is the energy and shields it breaks off
+	// a fight at
+	retreatEnergy = 2500
+
+	// phaserRange is how far off it will use phasers
+	phaserRange = 2.5
+)
+
+// Act takes the hunter's next action
+func (b *HunterBot) Act(g *galaxy.Galaxy) {
+	q := g.GetActiveQuadrant()
+
+	if hasEnemies(q) {
+		// A starbase more than makes good the hits taken
+		// while docked, so it is worth fighting from there
+		if q.NumberOfStarbases > 0 && (b.lowOnSupplies(g) || b.needsSupplies(g)) {
+			b.dock(g, q)
+			return
+		}
+		b.raiseShields(q)
+		if b.lowOnSupplies(g) && !q.Trapped() && b.retreat(g, q) {
+			return
+		}
+		b.attack(g, q)
+		return
+	}
+
+	if b.needsSupplies(g) {
+		if q.NumberOfStarbases > 0 {
+			b.dock(g, q)
+			return
+		}
+		b.raiseShields(q)
+		if !b.warpToStarbase(g, q) {
+			b.explore(g, q)
+		}
+		return
+	}
+
+	b.raiseShields(q)
+	tx, ty, found := nearestQuadrant(g, func(s *game.QuadrantSummary) bool {
+		return s.Scanned && s.Klingons > 0
+	})
+	if found && b.canReturn(g, q, tx, ty) {
+		b.warp(g, q, tx, ty)
+	} else {
+		b.explore(g, q)
+	}
+}
+
+// needsSupplies is true once the hunter should make for a
+// starbase between fights, and stays true until it is full
+func (b *HunterBot) needsSupplies(g *galaxy.Galaxy) bool {
+	p := g.Player
+	if p.Energy+p.Shields() < game.EnterpriseMaxEnergy*3/4 || p.Torpedoes < game.EnterpriseMaxTorpedoes/2 {
+		b.resupplying = true
+	} else if p.Energy+p.Shields() >= game.EnterpriseMaxEnergy-100 && p.Torpedoes == game.EnterpriseMaxTorpedoes {
+		b.resupplying = false
+	}
+	return b.resupplying
+}
+
+// lowOnSupplies is true once the hunter should break off a
+// fight
+func (b *HunterBot) lowOnSupplies(g *galaxy.Galaxy) bool {
+	p := g.Player
+	return p.Energy+p.Shields() < retreatEnergy || p.Torpedoes <= 1 && p.Energy < retreatEnergy
+}
+
+// raiseShields tops up any shield arc that has been worn down,
+// as long as it leaves the reserve alone
+func (b *HunterBot) raiseShields(q *quadrant.Quadrant) {
+	p := q.Player
+	for arc, s := range p.ShieldArcs {
+		if s < huntShields && p.Energy-(huntShields-s) > huntReserve {
+			q.SetShieldArc(arc, huntShields)
+		}
+	}
+}
+
+// retreat gets out of a losing fight, to a starbase if one is
+// known or else anywhere away from here
+func (b *HunterBot) retreat(g *galaxy.Galaxy, q *quadrant.Quadrant) bool {
+	return b.warpToStarbase(g, q) || b.explore(g, q)
+}
+
+// explore warps to the nearest quadrant the sensors haven't
+// swept yet
+func (b *HunterBot) explore(g *galaxy.Galaxy, q *quadrant.Quadrant) bool {
+	tx, ty, found := nearestQuadrant(g, func(s *game.QuadrantSummary) bool {
+		return !s.Scanned
+	})
+	return found && b.warp(g, q, tx, ty)
+}
+
+// canReturn checks there is the energy to warp to the given
+// quadrant and then on to the nearest starbase from there
+func (b *HunterBot) canReturn(g *galaxy.Galaxy, q *quadrant.Quadrant, tx, ty int) bool {
+	cost := q.WarpCost(q.X, q.Y, tx, ty)
+	if bx, by, found := nearestStarbase(g, tx, ty); found {
+		cost += q.WarpCost(tx, ty, bx, by)
+	}
+	return g.Player.Energy-cost > huntReserve/2
+}
+
+// warpToStarbase heads for the nearest starbase, returning
+// false if none is known or it can't be reached
+func (b *HunterBot) warpToStarbase(g *galaxy.Galaxy, q *quadrant.Quadrant) bool {
+	bx, by, found := nearestStarbase(g, q.X, q.Y)
+	if !found || bx == q.X && by == q.Y {
+		return false
+	}
+	return b.warp(g, q, bx, by)
+}
+
+// warp goes to the given quadrant, drawing on the shields
+// for the energy if it has to
+func (b *HunterBot) warp(g *galaxy.Galaxy, q *quadrant.Quadrant, x, y int) bool {
+	p := q.Player
+	if cost := q.WarpCost(q.X, q.Y, x, y); p.Energy < cost+huntReserve {
+		q.SetShields(game.Max(0, p.Energy+p.Shields()-cost-huntReserve))
+	}
+	if !q.Warp(x, y) {
+		return false
+	}
+	g.ScanNeighborQuadrants()
+	return true
+}
+
+// attack goes after the nearest enemy, with torpedoes while
+// they last and phasers close in
+func (b *HunterBot) attack(g *galaxy.Galaxy, q *quadrant.Quadrant) {
+	p := g.Player
+	tx, ty, ok := nearestObject(q, p.X, p.Y, isTarget(q))
+	if !ok {
+		return
+	}
+
+	// Phasers are dear, so they are kept for when the torpedoes
+	// run out
+	if distance := game.Distance(p.X, p.Y, tx, ty); p.Torpedoes <= 1 && distance <= phaserRange {
+		b.firePhasers(q, tx, ty, distance)
+		return
+	}
+
+	dir := quadrant.DirectionTo(p.X, p.Y, tx, ty)
+	if p.Torpedoes > 1 && linedUp(p.X, p.Y, tx, ty) && clearShot(q, p.X, p.Y, tx, ty, dir) {
+		if q.TorpedoesInFlight() == 0 {
+			q.FireTorpedo(dir)
+		}
+		return
+	}
+
+	if dir := b.approach(q, tx, ty); dir != quadrant.Dir5 {
+		g.MovePlayer(dir)
+	}
+}
+
+// shoot fires a torpedo at the nearest target that lines up,
+// without moving
+func (b *HunterBot) shoot(q *quadrant.Quadrant) {
+	p := q.Player
+	target := isTarget(q)
+	tx, ty, ok := nearestObject(q, p.X, p.Y, func(o quadrant.Object) bool {
+		x, y := o.Location()
+		return target(o) && linedUp(p.X, p.Y, x, y) && clearShot(q, p.X, p.Y, x, y, quadrant.DirectionTo(p.X, p.Y, x, y))
+	})
+	if ok && p.Torpedoes > 1 && q.TorpedoesInFlight() == 0 {
+		q.FireTorpedo(quadrant.DirectionTo(p.X, p.Y, tx, ty))
+	}
+}
+
+// firePhasers puts enough energy into the phasers to destroy
+// the target, if it can spare it
+func (b *HunterBot) firePhasers(q *quadrant.Quadrant, tx, ty int, distance float64) {
+	p := q.Player
+	// Every target in the quadrant shares the burst, and the
+	// least it can do is twice the energy over the distance
+	energy := q.Objects[tx][ty].GetShields() * len(phaserTargets(q)) * int(distance+0.5) / 2
+	energy = energy * 100 / game.Max(p.Efficiency(), 10)
+	if p.Energy-energy >= huntReserve {
+		q.FirePhasers(energy)
+	}
+}
+
+// approach picks the move that best lines the Enterprise up
+// for a shot at the target, or Dir5 to stay put
+func (b *HunterBot) approach(q *quadrant.Quadrant, tx, ty int) int {
+	p := q.Player
+	best, bestScore := quadrant.Dir5, math.MaxFloat64
+	for dir := 1; dir <= 9; dir++ {
+		x, y := p.X, p.Y
+		if dir != quadrant.Dir5 {
+			x, y = quadrant.NewLocation(p.X, p.Y, dir)
+			if !q.Size.Contains(x, y) || q.Objects[x][y] != nil {
+				continue
+			}
+		}
+		score := game.Distance(x, y, tx, ty)
+		if lined := linedUp(x, y, tx, ty); lined && clearShot(q, x, y, tx, ty, quadrant.DirectionTo(x, y, tx, ty)) {
+			score -= 100
+		} else if !lined {
+			dx, dy := game.Abs(tx-x), game.Abs(ty-y)
+			score += float64(game.Min(game.Min(dx, dy), game.Abs(dx-dy)))
+		}
+		if dir == quadrant.Dir5 {
+			// Staying put doesn't help a blocked shot
+			score += 1
+		}
+		if score < bestScore {
+			best, bestScore = dir, score
+		}
+	}
+	return best
+}
+
+// dock lowers the shields and moves alongside the nearest
+// starbase in the quadrant
+func (b *HunterBot) dock(g *galaxy.Galaxy, q *quadrant.Quadrant) {
+	p := g.Player
+	if p.Shields() > 0 {
+		q.SetShields(0)
+	}
+
+	bx, by, ok := nearestObject(q, p.X, p.Y, func(o quadrant.Object) bool {
+		_, isBase := o.(*quadrant.Starbase)
+		return isBase
+	})
+	if !ok {
+		return
+	}
+
+	dx, dy := bx-p.X, by-p.Y
+	if game.Abs(dx)+game.Abs(dy) == 1 {
+		// Docked, so wait for resupply, shooting at anything
+		// that lines up meanwhile
+		b.shoot(q)
+		return
+	}
+	if dir := closer(q, bx, by); dir != quadrant.Dir5 {
+		g.MovePlayer(dir)
+	}
+}
+
+// closer picks the move that takes the Enterprise nearest to
+// the given sector, or Dir5 if it is boxed in
+func closer(q *quadrant.Quadrant, tx, ty int) int {
+	p := q.Player
+	best, bestDistance := quadrant.Dir5, game.Distance(p.X, p.Y, tx, ty)
+	for dir := 1; dir <= 9; dir++ {
+		x, y := quadrant.NewLocation(p.X, p.Y, dir)
+		if dir == quadrant.Dir5 || !q.Size.Contains(x, y) || q.Objects[x][y] != nil {
+			continue
+		}
+		if d := game.Distance(x, y, tx, ty); d < bestDistance {
+			best, bestDistance = dir, d
+		}
+	}
+	return best
+}
+
+// hasEnemies checks for anything in the quadrant worth a fight
+func hasEnemies(q *quadrant.Quadrant) bool {
+	return q.NumberOfKlingons > 0 || q.VisibleRomulans() > 0 || q.Trapped()
+}
+
+// isTarget picks out the ships the hunter shoots at: the
+// Klingons, any Romulan that shows itself and a Tholian
+// whose web has closed
+func isTarget(q *quadrant.Quadrant) func(quadrant.Object) bool {
+	trapped := q.Trapped()
+	return func(o quadrant.Object) bool {
+		switch t := o.(type) {
+		case *quadrant.Romulan:
+			return !t.Cloaked
+		case *quadrant.Tholian:
+			return trapped
+		}
+		return quadrant.IsKlingon(o)
+	}
+}
+
+// phaserTargets lists what the phasers will share a burst
+// between
+func phaserTargets(q *quadrant.Quadrant) []quadrant.Object {
+	var result []quadrant.Object
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			switch o := q.Objects[x][y].(type) {
+			case *quadrant.Klingon, *quadrant.Commander, *quadrant.SuperCommander, *quadrant.Tholian:
+				result = append(result, o)
+			case *quadrant.Romulan:
+				if !o.Cloaked {
+					result = append(result, o)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// linedUp checks the target is along a row, column or diagonal
+func linedUp(x, y, tx, ty int) bool {
+	dx, dy := tx-x, ty-y
+	return dx == 0 || dy == 0 || game.Abs(dx) == game.Abs(dy)
+}
+
+// clearShot checks that nothing sits between the shooter and target
+func clearShot(q *quadrant.Quadrant, x, y, tx, ty, dir int) bool {
+	for {
+		x, y = quadrant.NewLocation(x, y, dir)
+		if !q.Size.Contains(x, y) {
+			return false
+		}
+		if x == tx && y == ty {
+			return true
+		}
+		if q.Objects[x][y] != nil {
+			return false
+		}
+	}
+}
+
+func nearestObject(q *quadrant.Quadrant, px, py int, match func(quadrant.Object) bool) (int, int, bool) {
+	bestX, bestY, best := 0, 0, math.MaxFloat64
+	for x := range q.Objects {
+		for y := range q.Objects[x] {
+			if o := q.Objects[x][y]; o != nil && match(o) {
+				if d := game.Distance(px, py, x, y); d < best {
+					bestX, bestY, best = x, y, d
+				}
+			}
+		}
+	}
+	return bestX, bestY, best < math.MaxFloat64
+}
+
+func nearestStarbase(g *galaxy.Galaxy, qx, qy int) (int, int, bool) {
+	bestX, bestY, best := 0, 0, math.MaxFloat64
+	for x := range g.Quadrants {
+		for y := range g.Quadrants[x] {
+			if s := g.GetQuadrantSummary(x, y); !s.Scanned || s.Starbases == 0 || s.Supernova {
+				continue
+			}
+			if d := game.Distance(qx, qy, x, y); d < best {
+				bestX, bestY, best = x, y, d
+			}
+		}
+	}
+	return bestX, bestY, best < math.MaxFloat64
+}
+
+func nearestQuadrant(g *galaxy.Galaxy, match func(*game.QuadrantSummary) bool) (int, int, bool) {
+	bestX, bestY, best := 0, 0, math.MaxFloat64
+	for x := range g.Quadrants {
+		for y := range g.Quadrants[x] {
+			s := g.GetQuadrantSummary(x, y)
+			if s.IsActive || !match(s) {
+				continue
+			}
+			if d := game.Distance(g.ActiveQuadrantX, g.ActiveQuadrantY, x, y); d < best {
+				bestX, bestY, best = x, y, d
+			}
+		}
+	}
+	return bestX, bestY, best < math.MaxFloat64
+}
@@ -0,0 +1,93 @@
+package simulate
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Summary aggregates the results of a batch of games
+type Summary struct {
+	Games                  int
+	Wins                   int
+	WinRate                float64
+	MeanStardatesToVictory float64
+	MeanKlingonsKilled     float64
+	MeanCommandersKilled   float64
+	MeanStarbasesLost      float64
+	Causes                 map[string]int
+}
+
+// Summarize aggregates a batch of results
+func Summarize(results []Result) Summary {
+	s := Summary{Games: len(results), Causes: map[string]int{}}
+	if len(results) == 0 {
+		return s
+	}
+
+	victoryTime, killed, commanders, lost := 0.0, 0, 0, 0
+	for _, r := range results {
+		if r.Won {
+			s.Wins++
+			victoryTime += r.Stardates
+		} else {
+			s.Causes[r.Cause]++
+		}
+		killed += r.KlingonsKilled
+		commanders += r.CommandersKilled
+		lost += r.StarbasesLost
+	}
+
+	s.WinRate = float64(s.Wins) / float64(s.Games)
+	if s.Wins > 0 {
+		s.MeanStardatesToVictory = victoryTime / float64(s.Wins)
+	}
+	s.MeanKlingonsKilled = float64(killed) / float64(s.Games)
+	s.MeanCommandersKilled = float64(commanders) / float64(s.Games)
+	s.MeanStarbasesLost = float64(lost) / float64(s.Games)
+	return s
+}
+
+// WriteTable prints the summary as a table
+func WriteTable(w io.Writer, s Summary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Games\t%d\n", s.Games)
+	fmt.Fprintf(tw, "Wins\t%d\n", s.Wins)
+	fmt.Fprintf(tw, "Win rate\t%.1f%%\n", s.WinRate*100)
+	fmt.Fprintf(tw, "Mean stardates to victory\t%.1f\n", s.MeanStardatesToVictory)
+	fmt.Fprintf(tw, "Mean Klingons killed\t%.2f\n", s.MeanKlingonsKilled)
+	fmt.Fprintf(tw, "Mean Commanders killed\t%.2f\n", s.MeanCommandersKilled)
+	fmt.Fprintf(tw, "Mean starbases lost\t%.2f\n", s.MeanStarbasesLost)
+
+	causes := make([]string, 0, len(s.Causes))
+	for c := range s.Causes {
+		causes = append(causes, c)
+	}
+	sort.Strings(causes)
+	for _, c := range causes {
+		fmt.Fprintf(tw, "Lost: %s\t%d\n", c, s.Causes[c])
+	}
+	return tw.Flush()
+}
+
+// WriteCSV writes one row per game, for analysis elsewhere
+func WriteCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"seed", "won", "stardates", "klingons_killed", "commanders_killed", "starbases_lost", "cause"})
+	for _, r := range results {
+		cw.Write([]string{
+			strconv.FormatInt(r.Seed, 10),
+			strconv.FormatBool(r.Won),
+			strconv.FormatFloat(r.Stardates, 'f', 1, 64),
+			strconv.Itoa(r.KlingonsKilled),
+			strconv.Itoa(r.CommandersKilled),
+			strconv.Itoa(r.StarbasesLost),
+			r.Cause,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,54 @@
+package simulate
+
+import (
+	"bytes"
+	"testing"
+)
+
+var testResults = []Result{
+	{Seed: 1, Won: true, Stardates: 40, KlingonsKilled: 25, CommandersKilled: 4},
+	{Seed: 2, Won: false, Stardates: 12.34, KlingonsKilled: 3, CommandersKilled: 1, StarbasesLost: 2, Cause: CauseCombat},
+	{Seed: 3, Won: true, Stardates: 60, KlingonsKilled: 25, CommandersKilled: 4, StarbasesLost: 1},
+	{Seed: 4, Won: false, Stardates: 7, KlingonsKilled: 1, StarbasesLost: 1, Cause: CauseCombat},
+	{Seed: 5, Won: false, Stardates: 500, KlingonsKilled: 6, CommandersKilled: 1, Cause: CauseTimeout},
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize(testResults)
+	if s.Games != 5 || s.Wins != 2 {
+		t.Errorf("got %d wins in %d games, want 2 in 5", s.Wins, s.Games)
+	}
+	if s.WinRate != 0.4 {
+		t.Errorf("got a win rate of %g, want 0.4", s.WinRate)
+	}
+	if s.MeanStardatesToVictory != 50 {
+		t.Errorf("got %g stardates to victory, want 50", s.MeanStardatesToVictory)
+	}
+	if s.MeanKlingonsKilled != 12 || s.MeanCommandersKilled != 2 || s.MeanStarbasesLost != 0.8 {
+		t.Errorf("got means of %g Klingons, %g Commanders and %g starbases, want 12, 2 and 0.8",
+			s.MeanKlingonsKilled, s.MeanCommandersKilled, s.MeanStarbasesLost)
+	}
+	if len(s.Causes) != 2 || s.Causes[CauseCombat] != 2 || s.Causes[CauseTimeout] != 1 {
+		t.Errorf("got causes %v", s.Causes)
+	}
+}
+
+func TestSummarizeNoGames(t *testing.T) {
+	s := Summarize(nil)
+	if s.Games != 0 || s.WinRate != 0 || s.MeanStardatesToVictory != 0 {
+		t.Errorf("got %+v for no games", s)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteCSV(&b, testResults[:2]); err != nil {
+		t.Fatal(err)
+	}
+	want := "seed,won,stardates,klingons_killed,commanders_killed,starbases_lost,cause\n" +
+		"1,true,40.0,25,4,0,\n" +
+		"2,false,12.3,3,1,2,destroyed in combat\n"
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
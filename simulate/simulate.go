@@ -0,0 +1,139 @@
+package simulate
+
+import (
+	"sync"
+
+	"github.com/hculpan/kabtrek/galaxy"
+	"github.com/hculpan/kabtrek/game"
+	"github.com/hculpan/kabtrek/scenario"
+)
+
+// Causes of a game ending without victory
+const (
+	CauseNone      = ""
+	CauseCombat    = "destroyed in combat"
+	CauseExhausted = "energy exhausted"
+	CauseTimeout   = "out of time"
+	CauseStarbases = "starbases lost"
+)
+
+// Config describes a batch of headless games
+type Config struct {
+	Games        int
+	Workers      int
+	Seed         int64
+	Bot          string
+	Klingons     int
+	Starbases    int
+	MaxStardates float64
+	Rules        *game.Rules
+
+	// Scenario, if set, replaces the random galaxy
+	Scenario *scenario.Scenario
+}
+
+// Result is the outcome of a single headless game.
+// KlingonsKilled doesn't include the Commanders and
+// Super-Commanders, which are in CommandersKilled.
+type Result struct {
+	Seed             int64
+	Won              bool
+	Stardates        float64
+	KlingonsKilled   int
+	CommandersKilled int
+	StarbasesLost    int
+	Cause            string
+}
+
+// Run plays every game in the batch across a pool of
+// workers, returning the results in seed order
+func Run(cfg Config) ([]Result, error) {
+	// Check the bot name up front rather than in every worker
+	if _, err := NewBot(cfg.Bot); err != nil {
+		return nil, err
+	}
+
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]Result, cfg.Games)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range jobs {
+				results[n] = Play(cfg, cfg.Seed+int64(n))
+			}
+		}()
+	}
+
+	for n := 0; n < cfg.Games; n++ {
+		jobs <- n
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+// Play runs a single headless game with the given seed
+func Play(cfg Config, seed int64) Result {
+	bot, _ := NewBot(cfg.Bot)
+
+	var g *galaxy.Galaxy
+	if cfg.Scenario != nil {
+		g = cfg.Scenario.Build(cfg.Rules, game.NewRandom(seed))
+	} else {
+		g = galaxy.NewGalaxyWithRules(cfg.Klingons, cfg.Starbases, cfg.Rules, game.NewRandom(seed))
+		g.PlacePlayer()
+	}
+	g.Headless = true
+
+	// The clock is driven by fake time, so games run as fast
+	// as they can be played
+	clock := &game.FakeTime{}
+	g.Clock = game.NewClockWith(clock.Now)
+
+	result := Result{Seed: seed}
+	hits := g.Player.Hits
+	for result.Cause == CauseNone && !result.Won {
+		switch g.Outcome() {
+		case game.Won:
+			result.Won = true
+		case game.PlayerDestroyed:
+			if g.Player.Hits > hits {
+				result.Cause = CauseCombat
+			} else {
+				result.Cause = CauseExhausted
+			}
+		case game.OutOfTime:
+			result.Cause = CauseTimeout
+		case game.StarbasesLost:
+			result.Cause = CauseStarbases
+		default:
+			if g.Stardate-g.StartingStardate >= cfg.MaxStardates {
+				result.Cause = CauseTimeout
+				continue
+			}
+			hits = g.Player.Hits
+			bot.Act(g)
+			clock.Advance(g.Clock.Interval())
+			for g.Clock.Due() {
+				g.Tick()
+			}
+		}
+	}
+
+	result.Stardates = g.Stardate - g.StartingStardate
+	// The galaxy counts Commanders among its Klingons, but
+	// they are reported on their own
+	result.CommandersKilled = g.StartingNumberOfCommanders - g.NumberOfCommanders +
+		g.StartingNumberOfSuperCommanders - g.NumberOfSuperCommanders
+	result.KlingonsKilled = g.StartingNumberOfKlingons - g.NumberOfKlingons - result.CommandersKilled
+	result.StarbasesLost = g.StartingNumberOfStarbases - g.NumberOfStarbases
+	return result
+}
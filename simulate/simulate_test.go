@@ -0,0 +1,67 @@
+package simulate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hculpan/kabtrek/game"
+)
+
+func testConfig(bot string, games, workers int) Config {
+	return Config{
+		Games:        games,
+		Workers:      workers,
+		Seed:         1,
+		Bot:          bot,
+		Klingons:     25,
+		Starbases:    5,
+		MaxStardates: 200,
+		Rules:        game.DefaultRules(),
+	}
+}
+
+func TestRunSameForAnyWorkers(t *testing.T) {
+	one, err := Run(testConfig("hunter", 8, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, workers := range []int{2, 5} {
+		many, err := Run(testConfig("hunter", 8, workers))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(one, many) {
+			t.Errorf("%d workers: got %v, want %v", workers, many, one)
+		}
+	}
+	for i, r := range one {
+		if r.Seed != int64(i+1) {
+			t.Errorf("result %d is for seed %d", i, r.Seed)
+		}
+	}
+}
+
+func TestRunUnknownBot(t *testing.T) {
+	if _, err := Run(testConfig("admiral", 1, 1)); err == nil {
+		t.Error("ran with an unknown bot")
+	}
+}
+
+func TestHunterBeatsIdle(t *testing.T) {
+	hunter, err := Run(testConfig("hunter", 20, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	idle, err := Run(testConfig("idle", 20, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, i := Summarize(hunter), Summarize(idle)
+	if h.Wins == 0 || h.Wins <= i.Wins {
+		t.Errorf("hunter won %d games and idle %d", h.Wins, i.Wins)
+	}
+	if h.MeanKlingonsKilled <= i.MeanKlingonsKilled {
+		t.Errorf("hunter killed %g Klingons a game and idle %g", h.MeanKlingonsKilled, i.MeanKlingonsKilled)
+	}
+}